@@ -18,16 +18,27 @@ import (
 const (
 	serviceAccount                   = "im_open_login_svc"
 	serviceOpenIM                    = "openim"
+	serviceRobot                     = "openim_robot_http_svc"
 	commandImportAccount             = "account_import"
 	commandImportAccounts            = "multiaccount_import"
 	commandDeleteAccounts            = "account_delete"
 	commandCheckAccounts             = "account_check"
 	commandKickAccount               = "kick"
 	commandQueryAccountsOnlineStatus = "query_online_status"
+	commandSetCustomStatus           = "set_user_custom_status"
+	commandGetCustomStatus           = "get_user_custom_status"
+	commandSubscribeStatus           = "subscribe_user_status"
+	commandUnsubscribeStatus         = "unsubscribe_user_status"
+	commandGetStatusSubscriptions    = "get_user_status_subscription"
+	commandCreateRobot               = "create_robot"
+	commandDeleteRobot               = "delete_robot"
+	commandFetchRobots               = "get_all_robots"
 
 	batchImportAccountsLimit = 100 // 导入账号限制
 	batchDeleteAccountsLimit = 100 // 删除账号限制
 	batchCheckAccountsLimit  = 100 // 查询账号限制
+	batchQueryOnlineLimit    = 500 // 查询在线状态限制
+	batchSubscribeLimit      = 100 // 订阅用户状态限制
 )
 
 type API interface {
@@ -90,6 +101,59 @@ type API interface {
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/2566
 	GetAccountsOnlineState(userIds []string, isNeedDetail ...bool) (ret *OnlineStatusRet, err error)
+
+	// SetCustomStatus 设置用户自定义状态
+	// 为指定用户设置自定义状态文本，自定义状态会通过状态订阅下发给订阅者。传入空字符串表示清除自定义状态。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/90458
+	SetCustomStatus(userId, customStatus string) (err error)
+
+	// GetCustomStatuses 查询用户自定义状态
+	// 批量查询用户的自定义状态，一次最多查询500个 UserID。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/90459
+	GetCustomStatuses(userIds ...string) (statuses map[string]string, err error)
+
+	// SubscribeStatus 订阅用户状态
+	// 订阅指定用户的在线状态及自定义状态，被订阅用户的状态变更会下发给订阅者，一次最多订阅100个 UserID。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/90460
+	SubscribeStatus(userId string, targetUserIds ...string) (failUserIds []string, err error)
+
+	// UnsubscribeStatus 取消订阅用户状态
+	// 取消订阅指定用户的在线状态及自定义状态，一次最多取消订阅100个 UserID。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/90461
+	UnsubscribeStatus(userId string, targetUserIds ...string) (failUserIds []string, err error)
+
+	// FetchStatusSubscriptions 获取用户状态订阅列表
+	// 分页获取指定用户订阅了状态的 UserID 列表。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/90462
+	FetchStatusSubscriptions(userId string, startIndex int) (ret *FetchStatusSubscriptionsRet, err error)
+
+	// PullStatusSubscriptions 续拉取用户状态订阅列表
+	// 本方法拓展于“获取用户状态订阅列表（FetchStatusSubscriptions）”方法。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/90462
+	PullStatusSubscriptions(userId string, fn func(ret *FetchStatusSubscriptionsRet)) (err error)
+
+	// CreateRobot 创建机器人账号
+	// 机器人账号的 UserID 必须以 @RBT# 开头，创建后可以像普通账号一样收发消息。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/89991
+	CreateRobot(robot *Robot) (err error)
+
+	// DeleteRobot 删除机器人账号
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/89992
+	DeleteRobot(userId string) (err error)
+
+	// FetchRobots 获取所有机器人账号
+	// 获取 App 中所有机器人账号的 UserID。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/89993
+	FetchRobots() (userIds []string, err error)
 }
 
 type api struct {
@@ -241,6 +305,16 @@ func (a *api) CheckAccounts(userIds ...string) (results []*CheckResult, err erro
 	return
 }
 
+// IsRobot 是否为机器人账号
+func (r *CheckResult) IsRobot() bool {
+	return IsRobot(r.UserId)
+}
+
+// IsRobot 判断 UserID 是否为机器人账号
+func IsRobot(userId string) bool {
+	return enum.IsRobot(userId)
+}
+
 // KickAccount 失效帐号登录状态
 // 本接口适用于将 App 用户帐号的登录状态（例如 UserSig）失效。
 // 例如，开发者判断一个用户为恶意帐号后，可以调用本接口将该用户当前的登录状态失效，这样用户使用历史 UserSig 登录即时通信 IM 会失败。
@@ -303,3 +377,181 @@ func (a *api) GetAccountsOnlineState(userIds []string, isNeedDetail ...bool) (re
 
 	return
 }
+
+// SetCustomStatus 设置用户自定义状态
+// 为指定用户设置自定义状态文本，自定义状态会通过状态订阅下发给订阅者。传入空字符串表示清除自定义状态。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/90458
+func (a *api) SetCustomStatus(userId, customStatus string) (err error) {
+	req := &setCustomStatusReq{UserId: userId, CustomStatus: customStatus}
+
+	if err = a.client.Post(serviceOpenIM, commandSetCustomStatus, req, &types.ActionBaseResp{}); err != nil {
+		return
+	}
+
+	return
+}
+
+// GetCustomStatuses 查询用户自定义状态
+// 批量查询用户的自定义状态，一次最多查询500个 UserID。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/90459
+func (a *api) GetCustomStatuses(userIds ...string) (statuses map[string]string, err error) {
+	if c := len(userIds); c == 0 {
+		err = core.NewError(enum.InvalidParamsCode, "the userid is not set")
+		return
+	} else if c > batchQueryOnlineLimit {
+		err = core.NewError(enum.InvalidParamsCode, fmt.Sprintf("the number of query accounts cannot exceed %d", batchQueryOnlineLimit))
+		return
+	}
+
+	req := &getCustomStatusesReq{UserIds: userIds}
+	resp := &getCustomStatusesResp{}
+
+	if err = a.client.Post(serviceOpenIM, commandGetCustomStatus, req, resp); err != nil {
+		return
+	}
+
+	statuses = make(map[string]string, len(resp.Results))
+	for _, item := range resp.Results {
+		statuses[item.UserId] = item.CustomStatus
+	}
+
+	return
+}
+
+// SubscribeStatus 订阅用户状态
+// 订阅指定用户的在线状态及自定义状态，被订阅用户的状态变更会下发给订阅者，一次最多订阅100个 UserID。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/90460
+func (a *api) SubscribeStatus(userId string, targetUserIds ...string) (failUserIds []string, err error) {
+	return a.operateStatusSubscription(commandSubscribeStatus, userId, targetUserIds...)
+}
+
+// UnsubscribeStatus 取消订阅用户状态
+// 取消订阅指定用户的在线状态及自定义状态，一次最多取消订阅100个 UserID。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/90461
+func (a *api) UnsubscribeStatus(userId string, targetUserIds ...string) (failUserIds []string, err error) {
+	return a.operateStatusSubscription(commandUnsubscribeStatus, userId, targetUserIds...)
+}
+
+// operateStatusSubscription 订阅或取消订阅用户状态
+func (a *api) operateStatusSubscription(command, userId string, targetUserIds ...string) (failUserIds []string, err error) {
+	if c := len(targetUserIds); c == 0 {
+		err = core.NewError(enum.InvalidParamsCode, "the target userid is not set")
+		return
+	} else if c > batchSubscribeLimit {
+		err = core.NewError(enum.InvalidParamsCode, fmt.Sprintf("the number of subscribe accounts cannot exceed %d", batchSubscribeLimit))
+		return
+	}
+
+	req := &subscribeStatusReq{UserId: userId, TargetUserIds: targetUserIds}
+	resp := &subscribeStatusResp{}
+
+	if err = a.client.Post(serviceOpenIM, command, req, resp); err != nil {
+		return
+	}
+
+	for _, item := range resp.Errors {
+		failUserIds = append(failUserIds, item.UserId)
+	}
+
+	return
+}
+
+// FetchStatusSubscriptions 获取用户状态订阅列表
+// 分页获取指定用户订阅了状态的 UserID 列表。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/90462
+func (a *api) FetchStatusSubscriptions(userId string, startIndex int) (ret *FetchStatusSubscriptionsRet, err error) {
+	req := &fetchStatusSubscriptionsReq{UserId: userId, StartIndex: startIndex}
+	resp := &fetchStatusSubscriptionsResp{}
+
+	if err = a.client.Post(serviceOpenIM, commandGetStatusSubscriptions, req, resp); err != nil {
+		return
+	}
+
+	ret = &FetchStatusSubscriptionsRet{
+		Next:    resp.NextStartIndex,
+		HasMore: resp.CompleteFlag == 0,
+		List:    resp.UserIds,
+	}
+
+	return
+}
+
+// PullStatusSubscriptions 续拉取用户状态订阅列表
+// 本方法拓展于“获取用户状态订阅列表（FetchStatusSubscriptions）”方法。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/90462
+func (a *api) PullStatusSubscriptions(userId string, fn func(ret *FetchStatusSubscriptionsRet)) (err error) {
+	var (
+		ret        *FetchStatusSubscriptionsRet
+		startIndex int
+	)
+
+	for ret == nil || ret.HasMore {
+		ret, err = a.FetchStatusSubscriptions(userId, startIndex)
+		if err != nil {
+			return
+		}
+
+		fn(ret)
+
+		if ret.HasMore {
+			startIndex = ret.Next
+		}
+	}
+
+	return
+}
+
+// CreateRobot 创建机器人账号
+// 机器人账号的 UserID 必须以 @RBT# 开头，创建后可以像普通账号一样收发消息。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/89991
+func (a *api) CreateRobot(robot *Robot) (err error) {
+	if !IsRobot(robot.UserId) {
+		err = core.NewError(enum.InvalidParamsCode, fmt.Sprintf("the robot's userid must start with %s", enum.RobotUserIdPrefix))
+		return
+	}
+
+	if err = a.client.Post(serviceRobot, commandCreateRobot, robot, &types.ActionBaseResp{}); err != nil {
+		return
+	}
+
+	return
+}
+
+// DeleteRobot 删除机器人账号
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/89992
+func (a *api) DeleteRobot(userId string) (err error) {
+	if !IsRobot(userId) {
+		err = core.NewError(enum.InvalidParamsCode, fmt.Sprintf("the robot's userid must start with %s", enum.RobotUserIdPrefix))
+		return
+	}
+
+	if err = a.client.Post(serviceRobot, commandDeleteRobot, &deleteRobotReq{userId}, &types.ActionBaseResp{}); err != nil {
+		return
+	}
+
+	return
+}
+
+// FetchRobots 获取所有机器人账号
+// 获取 App 中所有机器人账号的 UserID。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/89993
+func (a *api) FetchRobots() (userIds []string, err error) {
+	resp := &fetchRobotsResp{}
+
+	if err = a.client.Post(serviceRobot, commandFetchRobots, struct{}{}, resp); err != nil {
+		return
+	}
+
+	userIds = resp.UserIds
+
+	return
+}
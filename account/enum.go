@@ -14,3 +14,12 @@ const (
 	ImportedStatusNo  ImportedStatusType = "NotImported" // 未导入
 	ImportedStatusYes ImportedStatusType = "Imported"    // 已导入
 )
+
+// OnlineStatusType 在线状态
+type OnlineStatusType string
+
+const (
+	OnlineStatusOnline     OnlineStatusType = "Online"     // 前台运行状态
+	OnlineStatusPushOnline OnlineStatusType = "PushOnline" // 后台运行状态
+	OnlineStatusOffline    OnlineStatusType = "Offline"    // 未登录状态
+)
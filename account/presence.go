@@ -0,0 +1,227 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 11:20
+ * @Desc: 在线状态跟踪
+ */
+
+package account
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/default-yarns/tencent-im/callback"
+	"github.com/default-yarns/tencent-im/internal/enum"
+)
+
+const (
+	stateActionLogin      = "Login"      // 上线
+	stateActionLogout     = "Logout"     // 下线
+	stateActionDisconnect = "Disconnect" // 网络断开
+)
+
+type (
+	// PresenceChange 在线状态变更
+	PresenceChange struct {
+		UserId    string           // 用户的 UserID
+		Previous  OnlineStatusType // 变更前的在线状态，首次获取时为空
+		Current   OnlineStatusType // 变更后的在线状态
+		Action    string           // 触发变更的动作：Login、Logout、Disconnect，通过查询接口刷新时为空
+		Reason    string           // 触发变更的原因
+		EventTime int64            // 变更发生的毫秒级别时间戳
+	}
+
+	// PresenceTracker 在线状态跟踪器
+	// 通过“查询多个帐号在线状态（GetAccountsOnlineState）”初始化跟踪用户的在线状态，
+	// 并通过状态变更回调（callback.EventStateChange）持续更新内存中的在线状态表。
+	PresenceTracker struct {
+		api       API
+		mu        sync.RWMutex
+		presences map[string]*presence
+		listeners []func(change *PresenceChange)
+	}
+
+	presence struct {
+		status    OnlineStatusType
+		eventTime int64
+	}
+)
+
+func NewPresenceTracker(api API) *PresenceTracker {
+	return &PresenceTracker{
+		api:       api,
+		presences: make(map[string]*presence),
+	}
+}
+
+// OnChange 注册在线状态变更通知
+// 通知在状态发生实际变化时同步触发，请勿在通知函数中执行耗时操作。
+func (t *PresenceTracker) OnChange(fn func(change *PresenceChange)) {
+	t.mu.Lock()
+	t.listeners = append(t.listeners, fn)
+	t.mu.Unlock()
+}
+
+// Track 跟踪用户的在线状态
+// 跟踪时会立即查询一次用户的在线状态，每批最多查询500个 UserID。
+func (t *PresenceTracker) Track(userIds ...string) (err error) {
+	for i := 0; i < len(userIds); i += batchQueryOnlineLimit {
+		end := i + batchQueryOnlineLimit
+		if end > len(userIds) {
+			end = len(userIds)
+		}
+
+		if err = t.query(userIds[i:end]); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Untrack 取消跟踪用户的在线状态
+func (t *PresenceTracker) Untrack(userIds ...string) {
+	t.mu.Lock()
+	for _, userId := range userIds {
+		delete(t.presences, userId)
+	}
+	t.mu.Unlock()
+}
+
+// Refresh 重新查询所有跟踪用户的在线状态
+// 可用于服务重启或回调丢失后的状态校准。
+func (t *PresenceTracker) Refresh() (err error) {
+	t.mu.RLock()
+	userIds := make([]string, 0, len(t.presences))
+	for userId := range t.presences {
+		userIds = append(userIds, userId)
+	}
+	t.mu.RUnlock()
+
+	return t.Track(userIds...)
+}
+
+// Listen 监听状态变更回调
+// 为回调注册 EventStateChange 事件处理函数，收到回调后更新跟踪用户的在线状态并应答成功。
+func (t *PresenceTracker) Listen(cb callback.Callback) {
+	cb.Register(callback.EventStateChange, func(ctx context.Context, ack callback.Ack, data interface{}) {
+		if change, ok := data.(*callback.StateChange); ok {
+			t.HandleStateChange(change)
+		}
+
+		_ = ack.AckSuccess(enum.SuccessCode)
+	})
+}
+
+// HandleStateChange 处理状态变更回调
+// 仅更新已跟踪用户的在线状态，早于当前状态的回调事件会被忽略。
+func (t *PresenceTracker) HandleStateChange(data *callback.StateChange) {
+	var status OnlineStatusType
+
+	switch data.Info.Action {
+	case stateActionLogin:
+		status = OnlineStatusOnline
+	case stateActionLogout, stateActionDisconnect:
+		status = OnlineStatusOffline
+	default:
+		return
+	}
+
+	t.update(&PresenceChange{
+		UserId:    data.Info.UserId,
+		Current:   status,
+		Action:    data.Info.Action,
+		Reason:    data.Info.Reason,
+		EventTime: data.EventTime,
+	}, false)
+}
+
+// GetStatus 获取用户的在线状态
+func (t *PresenceTracker) GetStatus(userId string) (status OnlineStatusType, ok bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if p, exist := t.presences[userId]; exist {
+		return p.status, true
+	}
+
+	return
+}
+
+// IsOnline 用户是否在线（前台运行或后台运行）
+func (t *PresenceTracker) IsOnline(userId string) bool {
+	status, _ := t.GetStatus(userId)
+	return status == OnlineStatusOnline || status == OnlineStatusPushOnline
+}
+
+// GetAllStatus 获取所有跟踪用户的在线状态
+func (t *PresenceTracker) GetAllStatus() map[string]OnlineStatusType {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	statuses := make(map[string]OnlineStatusType, len(t.presences))
+	for userId, p := range t.presences {
+		statuses[userId] = p.status
+	}
+
+	return statuses
+}
+
+// query 查询用户的在线状态
+func (t *PresenceTracker) query(userIds []string) (err error) {
+	var ret *OnlineStatusRet
+
+	eventTime := time.Now().UnixNano() / int64(time.Millisecond)
+
+	if ret, err = t.api.GetAccountsOnlineState(userIds); err != nil {
+		return
+	}
+
+	for _, item := range ret.Results {
+		t.update(&PresenceChange{
+			UserId:    item.UserId,
+			Current:   OnlineStatusType(item.Status),
+			EventTime: eventTime,
+		}, true)
+	}
+
+	return
+}
+
+// update 更新用户的在线状态
+func (t *PresenceTracker) update(change *PresenceChange, isTrack bool) {
+	t.mu.Lock()
+
+	p, ok := t.presences[change.UserId]
+	if !ok {
+		if !isTrack {
+			t.mu.Unlock()
+			return
+		}
+
+		p = &presence{}
+		t.presences[change.UserId] = p
+	}
+
+	if change.EventTime < p.eventTime {
+		t.mu.Unlock()
+		return
+	}
+
+	change.Previous = p.status
+	p.status = change.Current
+	p.eventTime = change.EventTime
+	listeners := t.listeners
+
+	t.mu.Unlock()
+
+	if change.Previous == change.Current {
+		return
+	}
+
+	for _, fn := range listeners {
+		fn(change)
+	}
+}
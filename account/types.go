@@ -72,6 +72,25 @@ type (
 		ResultInfo string             `json:"ResultInfo"`    // 单个帐号检查失败时的错误描述信息
 	}
 
+	// Robot 机器人账号
+	Robot struct {
+		UserId        string `json:"UserID"`                  // （必填）机器人的 UserID，必须以 @RBT# 开头，长度不超过32字节
+		Nickname      string `json:"Nick,omitempty"`          // （选填）机器人昵称
+		FaceUrl       string `json:"FaceUrl,omitempty"`       // （选填）机器人头像 URL
+		SelfSignature string `json:"SelfSignature,omitempty"` // （选填）机器人个性签名
+	}
+
+	// 删除机器人账号（请求）
+	deleteRobotReq struct {
+		UserId string `json:"UserID"` // （必填）需要删除的机器人的 UserID
+	}
+
+	// 获取所有机器人账号（响应）
+	fetchRobotsResp struct {
+		types.ActionBaseResp
+		UserIds []string `json:"RobotAccounts"` // 机器人的 UserID 列表
+	}
+
 	// 失效帐号登录状态（请求）
 	kickAccountReq struct {
 		UserId string `json:"Identifier"` // （必填）用户名
@@ -114,4 +133,61 @@ type (
 		UserId    string `json:"To_Account"` // 状态查询失败的目标帐号
 		ErrorCode int    `json:"ErrorCode"`  // 状态查询失败的错误码，若目标帐号的错误码为70107，表示该帐号不存在
 	}
+
+	// 设置用户自定义状态（请求）
+	setCustomStatusReq struct {
+		UserId       string `json:"From_Account"` // （必填）需要设置自定义状态的 UserID
+		CustomStatus string `json:"CustomStatus"` // （必填）用户自定义状态，为空字符串时表示清除自定义状态
+	}
+
+	// 查询用户自定义状态（请求）
+	getCustomStatusesReq struct {
+		UserIds []string `json:"To_Account"` // （必填）需要查询自定义状态的 UserID 列表
+	}
+
+	// 查询用户自定义状态（响应）
+	getCustomStatusesResp struct {
+		types.ActionBaseResp
+		Results []customStatusItem  `json:"QueryResult"` // 用户自定义状态列表
+		Errors  []OnlineStatusError `json:"ErrorList"`   // 查询失败的帐号列表
+	}
+
+	// 用户自定义状态
+	customStatusItem struct {
+		UserId       string `json:"To_Account"`   // 用户的 UserID
+		CustomStatus string `json:"CustomStatus"` // 用户自定义状态
+	}
+
+	// 订阅用户状态（请求）
+	subscribeStatusReq struct {
+		UserId        string   `json:"From_Account"` // （必填）订阅者的 UserID
+		TargetUserIds []string `json:"To_Account"`   // （必填）被订阅者的 UserID 列表
+	}
+
+	// 订阅用户状态（响应）
+	subscribeStatusResp struct {
+		types.ActionBaseResp
+		Errors []OnlineStatusError `json:"ErrorList"` // 订阅失败的帐号列表
+	}
+
+	// 获取用户状态订阅列表（请求）
+	fetchStatusSubscriptionsReq struct {
+		UserId     string `json:"From_Account"`         // （必填）订阅者的 UserID
+		StartIndex int    `json:"StartIndex,omitempty"` // （选填）拉取的起始位置，第一页填 0
+	}
+
+	// 获取用户状态订阅列表（响应）
+	fetchStatusSubscriptionsResp struct {
+		types.ActionBaseResp
+		CompleteFlag   int      `json:"CompleteFlag"`   // 结束标识：1 表示已全部返回，0 表示还有数据没拉完
+		NextStartIndex int      `json:"NextStartIndex"` // 下一页拉取的起始位置
+		UserIds        []string `json:"To_Account"`     // 被订阅者的 UserID 列表
+	}
+
+	// FetchStatusSubscriptionsRet 获取用户状态订阅列表（返回）
+	FetchStatusSubscriptionsRet struct {
+		Next    int      // 下一页拉取的起始位置
+		HasMore bool     // 是否还有更多数据
+		List    []string // 被订阅者的 UserID 列表
+	}
 )
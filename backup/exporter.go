@@ -0,0 +1,476 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 18:00
+ * @Desc: 全量/增量备份导出，输出为 JSON Lines 格式
+ */
+
+package backup
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/default-yarns/tencent-im/group"
+	"github.com/default-yarns/tencent-im/profile"
+	"github.com/default-yarns/tencent-im/recentcontact"
+	"github.com/default-yarns/tencent-im/sns"
+)
+
+const (
+	batchFetchGroupsLimit   = 50   // 每次拉取群组的最大数量
+	batchFetchMembersLimit  = 500  // 每次拉取群成员的最大数量
+	batchFetchMessagesLimit = 20   // 每次拉取群消息的最大数量
+	batchGetProfilesLimit   = 100  // 每次拉取资料的最大数量
+	batchFetchBlacklist     = 1000 // 每次拉取黑名单的最大数量
+)
+
+// 默认备份的资料字段
+var defaultProfileAttrs = []string{
+	profile.StandardAttrNickname,
+	profile.StandardAttrGender,
+	profile.StandardAttrBirthday,
+	profile.StandardAttrLocation,
+	profile.StandardAttrSignature,
+	profile.StandardAttrAllowType,
+	profile.StandardAttrLanguage,
+	profile.StandardAttrAvatar,
+	profile.StandardAttrMsgSettings,
+	profile.StandardAttrAdminForbidType,
+	profile.StandardAttrLevel,
+	profile.StandardAttrRole,
+}
+
+// Exporter 备份导出器
+// 依次导出群组、群成员、群消息、用户资料、好友、黑名单和会话，每条实体写为一行 JSON 记录并附带 SHA256 校验值。
+// 指定增量备份状态文件时，群资料（LastInfoTime）未变更的群组跳过群组与群成员的导出，
+// 群消息（LastMsgTime）未变更的群组跳过群消息的导出，已变更的群组仅导出上次备份之后的新消息。
+// 增量时间仅在对应实体全部导出后推进：群资料时间需同时导出群组与群成员，群消息时间需导出群消息。
+type Exporter struct {
+	client   Client
+	opt      Options
+	entities map[Entity]bool
+	writer   *bufio.Writer
+	summary  *Summary
+}
+
+func NewExporter(client Client, opt *Options) *Exporter {
+	e := &Exporter{client: client}
+
+	if opt != nil {
+		e.opt = *opt
+	}
+
+	if len(e.opt.Entities) == 0 {
+		e.opt.Entities = defaultEntities
+	}
+
+	if len(e.opt.ProfileAttrs) == 0 {
+		e.opt.ProfileAttrs = defaultProfileAttrs
+	}
+
+	e.entities = make(map[Entity]bool, len(e.opt.Entities))
+	for _, entity := range e.opt.Entities {
+		e.entities[entity] = true
+	}
+
+	return e
+}
+
+// Export 执行备份并写入指定的输出
+// 备份成功后才会更新增量备份状态文件，备份中断时下次仍从上次成功的状态开始。
+func (e *Exporter) Export(w io.Writer) (summary *Summary, err error) {
+	st, err := loadState(e.opt.StateFile)
+	if err != nil {
+		return
+	}
+
+	e.writer = bufio.NewWriter(w)
+	e.summary = &Summary{Counts: make(map[Entity]int)}
+
+	if err = e.write(EntityHeader, "", &Header{
+		Version:     Version,
+		CreatedAt:   time.Now().Unix(),
+		Incremental: st.UpdatedAt > 0,
+		Entities:    e.opt.Entities,
+	}); err != nil {
+		return
+	}
+
+	if e.entities[EntityGroup] || e.entities[EntityMember] || e.entities[EntityGroupMessage] {
+		if err = e.exportGroups(st); err != nil {
+			return
+		}
+	}
+
+	if e.entities[EntityProfile] {
+		if err = e.exportProfiles(); err != nil {
+			return
+		}
+	}
+
+	for _, userId := range e.opt.UserIds {
+		if e.entities[EntityFriend] {
+			if err = e.exportFriends(userId); err != nil {
+				return
+			}
+		}
+
+		if e.entities[EntityBlacklist] {
+			if err = e.exportBlacklist(userId); err != nil {
+				return
+			}
+		}
+
+		if e.entities[EntitySession] {
+			if err = e.exportSessions(userId); err != nil {
+				return
+			}
+		}
+	}
+
+	counts := make(map[Entity]int, len(e.summary.Counts))
+	for entity, count := range e.summary.Counts {
+		counts[entity] = count
+	}
+
+	if err = e.write(EntityFooter, "", &Footer{Counts: counts}); err != nil {
+		return
+	}
+
+	if err = e.writer.Flush(); err != nil {
+		return
+	}
+
+	st.UpdatedAt = time.Now().Unix()
+	if err = st.save(e.opt.StateFile); err != nil {
+		return
+	}
+
+	summary = e.summary
+
+	return
+}
+
+// exportGroups 导出群组、群成员与群消息
+func (e *Exporter) exportGroups(st *state) (err error) {
+	groups := make([]*group.Group, 0)
+	if err = e.client.Group().PullGroups(&group.PullGroupsArg{
+		Limit: batchFetchGroupsLimit,
+		Type:  e.opt.GroupType,
+	}, func(ret *group.FetchGroupsRet) {
+		groups = append(groups, ret.List...)
+	}); err != nil {
+		return
+	}
+
+	for _, g := range groups {
+		// 拉取群资料失败时中止备份，避免写入空的群组数据并更新增量状态
+		if err = g.GetError(); err != nil {
+			return fmt.Errorf("fetch group %s failed: %w", g.GetGroupId(), err)
+		}
+
+		var (
+			groupId      = g.GetGroupId()
+			lastInfoTime = g.GetLastInfoTime().Unix()
+			lastMsgTime  = g.GetLastMsgTime().Unix()
+			gs, ok       = st.Groups[groupId]
+		)
+
+		if !ok {
+			gs = &groupState{}
+		}
+
+		// 直播群不导出群成员与群消息；仅在增量状态覆盖的实体全部导出后才推进对应的时间，
+		// 避免本次未导出的实体在后续包含该实体的备份中被误判为未变更而跳过
+		var (
+			liveRoom    = g.GetGroupType() == group.TypeLiveRoom
+			exportInfo  = e.entities[EntityGroup] && (e.entities[EntityMember] || liveRoom)
+			exportMsg   = e.entities[EntityGroupMessage] && !liveRoom
+			infoChanged = !ok || lastInfoTime > gs.LastInfoTime
+			msgChanged  = !liveRoom && (!ok || lastMsgTime > gs.LastMsgTime)
+		)
+
+		// 本次备份不涉及的变更不计入导出
+		infoChanged = infoChanged && (e.entities[EntityGroup] || e.entities[EntityMember])
+		msgChanged = msgChanged && exportMsg
+
+		if !infoChanged && !msgChanged {
+			e.summary.SkippedGroups++
+			continue
+		}
+
+		if infoChanged {
+			if e.entities[EntityGroup] {
+				if err = e.write(EntityGroup, groupId, newGroupData(g)); err != nil {
+					return
+				}
+			}
+
+			if e.entities[EntityMember] && !liveRoom {
+				if err = e.exportMembers(groupId); err != nil {
+					return
+				}
+			}
+		}
+
+		if msgChanged {
+			if gs.LastMsgSeq, err = e.exportMessages(groupId, gs.LastMsgSeq); err != nil {
+				return
+			}
+		}
+
+		if infoChanged && exportInfo {
+			gs.LastInfoTime = lastInfoTime
+		}
+
+		if msgChanged {
+			gs.LastMsgTime = lastMsgTime
+		}
+
+		st.Groups[groupId] = gs
+	}
+
+	return
+}
+
+// exportMembers 导出群成员
+func (e *Exporter) exportMembers(groupId string) (err error) {
+	members := make([]*group.Member, 0)
+	if err = e.client.Group().PullMembers(&group.PullMembersArg{
+		GroupId: groupId,
+		Limit:   batchFetchMembersLimit,
+	}, func(ret *group.FetchMembersRet) {
+		members = append(members, ret.List...)
+	}); err != nil {
+		return
+	}
+
+	for _, member := range members {
+		if err = e.write(EntityMember, groupId+"|"+member.GetUserId(), &MemberData{
+			GroupId:      groupId,
+			UserId:       member.GetUserId(),
+			Role:         member.GetRole(),
+			NameCard:     member.GetNameCard(),
+			JoinTime:     member.GetJoinTime().Unix(),
+			MsgSeq:       member.GetMsgSeq(),
+			MsgFlag:      string(member.GetMsgFlag()),
+			ShutUpUntil:  member.GetShutUpUntil(),
+			UnreadMsgNum: member.GetUnreadMsgNum(),
+			CustomData:   member.GetAllCustomData(),
+		}); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// exportMessages 导出序列号大于 afterSeq 的群消息，按序列号升序写入，返回最后一条消息的序列号
+func (e *Exporter) exportMessages(groupId string, afterSeq int) (lastSeq int, err error) {
+	var (
+		ret      *group.FetchMessagesRet
+		msgSeq   int
+		messages = make([]*group.Message, 0)
+	)
+
+	lastSeq = afterSeq
+
+fetch:
+	for ret == nil || ret.HasMore {
+		if ret, err = e.client.Group().FetchMessages(groupId, batchFetchMessagesLimit, msgSeq); err != nil {
+			return
+		}
+
+		for _, message := range ret.List {
+			if message.GetSerialNo() <= afterSeq {
+				break fetch
+			}
+			messages = append(messages, message)
+		}
+
+		msgSeq = ret.NextSeq
+	}
+
+	for i := len(messages) - 1; i >= 0; i-- {
+		message := messages[i]
+		if err = e.write(EntityGroupMessage, fmt.Sprintf("%s|%d", groupId, message.GetSerialNo()), &GroupMessageData{
+			GroupId:   groupId,
+			MsgSeq:    message.GetSerialNo(),
+			Sender:    message.GetSender(),
+			MsgTime:   message.GetTimestamp(),
+			MsgRandom: message.GetRandom(),
+			Status:    int(message.GetStatus()),
+			MsgBody:   message.GetBody(),
+		}); err != nil {
+			return
+		}
+
+		if message.GetSerialNo() > lastSeq {
+			lastSeq = message.GetSerialNo()
+		}
+	}
+
+	return
+}
+
+// exportProfiles 导出用户资料
+func (e *Exporter) exportProfiles() (err error) {
+	for i := 0; i < len(e.opt.UserIds); i += batchGetProfilesLimit {
+		end := i + batchGetProfilesLimit
+		if end > len(e.opt.UserIds) {
+			end = len(e.opt.UserIds)
+		}
+
+		var profiles []*profile.Profile
+		if profiles, err = e.client.Profile().GetProfiles(e.opt.UserIds[i:end], e.opt.ProfileAttrs); err != nil {
+			return
+		}
+
+		for _, p := range profiles {
+			if !p.IsValid() {
+				continue
+			}
+
+			if err = e.write(EntityProfile, p.GetUserId(), &ProfileData{
+				UserId: p.GetUserId(),
+				Attrs:  p.GetAttrs(),
+			}); err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+// exportFriends 导出好友
+func (e *Exporter) exportFriends(userId string) (err error) {
+	friends := make([]*sns.Friend, 0)
+	if err = e.client.SNS().PullFriends(userId, func(ret *sns.FetchFriendsRet) {
+		friends = append(friends, ret.List...)
+	}); err != nil {
+		return
+	}
+
+	for _, friend := range friends {
+		if err = e.write(EntityFriend, userId+"|"+friend.GetUserId(), &FriendData{
+			UserId:       userId,
+			FriendUserId: friend.GetUserId(),
+			Attrs:        friend.GetSNSAttrs(),
+		}); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// exportBlacklist 导出黑名单
+func (e *Exporter) exportBlacklist(userId string) (err error) {
+	list := make([]*sns.Blacklist, 0)
+	if err = e.client.SNS().PullBlacklist(userId, batchFetchBlacklist, func(ret *sns.FetchBlacklistRet) {
+		list = append(list, ret.List...)
+	}); err != nil {
+		return
+	}
+
+	for _, item := range list {
+		if err = e.write(EntityBlacklist, userId+"|"+item.UserId, &BlacklistData{
+			UserId:        userId,
+			BlackedUserId: item.UserId,
+			Time:          item.Time,
+		}); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// exportSessions 导出会话
+func (e *Exporter) exportSessions(userId string) (err error) {
+	sessions := make([]*recentcontact.SessionItem, 0)
+	if err = e.client.RecentContact().PullSessions(&recentcontact.PullSessionsArg{
+		UserId:                  userId,
+		IsAllowTopSession:       true,
+		IsReturnEmptySession:    true,
+		IsAllowTopSessionPaging: true,
+	}, func(ret *recentcontact.FetchSessionsRet) {
+		sessions = append(sessions, ret.List...)
+	}); err != nil {
+		return
+	}
+
+	for _, session := range sessions {
+		peer := session.UserId
+		if session.Type == recentcontact.SessionTypeG2C {
+			peer = session.GroupId
+		}
+
+		if err = e.write(EntitySession, fmt.Sprintf("%s|%d|%s", userId, session.Type, peer), &SessionData{
+			UserId:  userId,
+			Session: session,
+		}); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// write 写入一条备份记录
+func (e *Exporter) write(entity Entity, key string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	line, err := json.Marshal(&Record{
+		Type:     entity,
+		Key:      key,
+		Checksum: checksum(data),
+		Data:     data,
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err = e.writer.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	if entity != EntityHeader && entity != EntityFooter {
+		e.summary.Counts[entity]++
+
+		if e.opt.OnProgress != nil {
+			e.opt.OnProgress(entity, key)
+		}
+	}
+
+	return nil
+}
+
+func newGroupData(g *group.Group) *GroupData {
+	return &GroupData{
+		GroupId:         g.GetGroupId(),
+		Type:            string(g.GetGroupType()),
+		Name:            g.GetName(),
+		Owner:           g.GetOwner(),
+		Introduction:    g.GetIntroduction(),
+		Notification:    g.GetNotification(),
+		Avatar:          g.GetAvatar(),
+		MaxMemberNum:    g.GetMaxMemberNum(),
+		MemberNum:       g.GetMemberNum(),
+		ApplyJoinOption: g.GetApplyJoinOption(),
+		ShutUpStatus:    g.GetShutUpStatus(),
+		CreateTime:      g.GetCreateTime(),
+		LastInfoTime:    g.GetLastInfoTime().Unix(),
+		LastMsgTime:     g.GetLastMsgTime().Unix(),
+		NextMsgSeq:      g.GetNextMsgSeq(),
+		CustomData:      g.GetAllCustomData(),
+	}
+}
@@ -0,0 +1,177 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 18:00
+ * @Desc: 备份文件读取
+ */
+
+package backup
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const maxRecordSize = 16 * 1024 * 1024 // 单条记录的最大长度
+
+// Reader 备份文件读取器
+type Reader struct {
+	scanner *bufio.Scanner
+	header  *Header
+	footer  *Footer
+	counts  map[Entity]int
+	line    int
+}
+
+// NewReader 创建备份文件读取器，并读取校验备份文件头
+func NewReader(r io.Reader) (*Reader, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxRecordSize)
+
+	reader := &Reader{scanner: scanner, counts: make(map[Entity]int)}
+
+	record, err := reader.read()
+	if err != nil {
+		if err == io.EOF {
+			err = fmt.Errorf("the backup header is missing")
+		}
+		return nil, err
+	}
+
+	if record.Type != EntityHeader {
+		return nil, fmt.Errorf("the backup header is missing")
+	}
+
+	header := &Header{}
+	if err = record.Decode(header); err != nil {
+		return nil, err
+	}
+
+	if header.Version > Version {
+		return nil, fmt.Errorf("unsupported backup version: %d", header.Version)
+	}
+
+	reader.header = header
+
+	return reader, nil
+}
+
+// Header 获取备份文件头
+func (r *Reader) Header() *Header {
+	return r.header
+}
+
+// Footer 获取备份文件尾，读取到文件尾之前返回 nil
+func (r *Reader) Footer() *Footer {
+	return r.footer
+}
+
+// Next 读取下一条记录并校验数据，读取到文件尾并校验记录数一致后返回 io.EOF；
+// 文件尾缺失（备份文件被截断）、文件尾之后仍有记录或记录数与文件尾不一致时返回错误
+func (r *Reader) Next() (*Record, error) {
+	if r.footer != nil {
+		if _, err := r.read(); err != io.EOF {
+			if err == nil {
+				err = fmt.Errorf("line %d: unexpected record after the backup footer", r.line)
+			}
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+
+	record, err := r.read()
+	if err != nil {
+		if err == io.EOF {
+			err = fmt.Errorf("the backup footer is missing, the backup may be truncated")
+		}
+		return nil, err
+	}
+
+	switch record.Type {
+	case EntityHeader:
+		return nil, fmt.Errorf("line %d: unexpected backup header", r.line)
+	case EntityFooter:
+		footer := &Footer{}
+		if err = record.Decode(footer); err != nil {
+			return nil, fmt.Errorf("line %d: %s", r.line, err)
+		}
+
+		if err = r.checkCounts(footer); err != nil {
+			return nil, err
+		}
+
+		r.footer = footer
+
+		return r.Next()
+	}
+
+	r.counts[record.Type]++
+
+	return record, nil
+}
+
+// checkCounts 校验读取的记录数与文件尾记录的一致
+func (r *Reader) checkCounts(footer *Footer) error {
+	for entity, count := range footer.Counts {
+		if r.counts[entity] != count {
+			return fmt.Errorf("the number of %s records mismatch, expected %d but got %d", entity, count, r.counts[entity])
+		}
+	}
+
+	for entity, count := range r.counts {
+		if _, ok := footer.Counts[entity]; !ok {
+			return fmt.Errorf("the number of %s records mismatch, expected 0 but got %d", entity, count)
+		}
+	}
+
+	return nil
+}
+
+// read 读取下一条记录并校验数据，读取完毕时返回 io.EOF
+func (r *Reader) read() (*Record, error) {
+	for r.scanner.Scan() {
+		r.line++
+
+		line := r.scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		record := &Record{}
+		if err := json.Unmarshal(line, record); err != nil {
+			return nil, fmt.Errorf("line %d: %s", r.line, err)
+		}
+
+		if !record.Verify() {
+			return nil, fmt.Errorf("line %d: checksum mismatch for %s %s", r.line, record.Type, record.Key)
+		}
+
+		return record, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, io.EOF
+}
+
+// Verify 校验记录数据是否完整，缺少校验值的记录视为不完整
+func (r *Record) Verify() bool {
+	return r.Checksum != "" && r.Checksum == checksum(r.Data)
+}
+
+// Decode 解析记录数据
+func (r *Record) Decode(v interface{}) error {
+	return json.Unmarshal(r.Data, v)
+}
+
+// checksum 计算数据的 SHA256 校验值
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,125 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/19 11:00
+ * @Desc: 备份文件读写测试
+ */
+
+package backup
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/default-yarns/tencent-im/group"
+	"github.com/default-yarns/tencent-im/profile"
+	"github.com/default-yarns/tencent-im/recentcontact"
+	"github.com/default-yarns/tencent-im/sns"
+)
+
+type stubClient struct{}
+
+type stubProfileAPI struct {
+	profile.API
+}
+
+func (stubClient) Profile() profile.API             { return stubProfileAPI{} }
+func (stubClient) SNS() sns.API                     { return nil }
+func (stubClient) Group() group.API                 { return nil }
+func (stubClient) RecentContact() recentcontact.API { return nil }
+
+func (stubProfileAPI) GetProfiles(userIds []string, attrs []string) ([]*profile.Profile, error) {
+	profiles := make([]*profile.Profile, 0, len(userIds))
+	for _, userId := range userIds {
+		p := profile.NewProfile(userId)
+		p.SetNickname("nick-" + userId)
+		profiles = append(profiles, p)
+	}
+
+	return profiles, nil
+}
+
+func exportProfiles(t *testing.T, userIds ...string) []byte {
+	buf := &bytes.Buffer{}
+	if _, err := NewExporter(stubClient{}, &Options{
+		UserIds:  userIds,
+		Entities: []Entity{EntityProfile},
+	}).Export(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func readAll(data []byte) ([]*Record, error) {
+	reader, err := NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*Record, 0)
+	for {
+		record, err := reader.Next()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+}
+
+func TestReader_RoundTrip(t *testing.T) {
+	data := exportProfiles(t, "u1", "u2")
+
+	records, err := readAll(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+
+	for i, userId := range []string{"u1", "u2"} {
+		pd := &ProfileData{}
+		if err = records[i].Decode(pd); err != nil {
+			t.Fatal(err)
+		}
+
+		if records[i].Type != EntityProfile || pd.UserId != userId || pd.Attrs[profile.StandardAttrNickname] != "nick-"+userId {
+			t.Fatalf("unexpected record: %+v", pd)
+		}
+	}
+}
+
+func TestReader_Truncated(t *testing.T) {
+	lines := strings.SplitAfter(string(exportProfiles(t, "u1", "u2")), "\n")
+
+	// 去掉文件尾
+	if _, err := readAll([]byte(strings.Join(lines[:len(lines)-2], ""))); err == nil || !strings.Contains(err.Error(), "footer is missing") {
+		t.Fatalf("expected missing footer error, got %v", err)
+	}
+
+	// 去掉一条记录，文件尾的记录数不一致
+	if _, err := readAll([]byte(lines[0] + strings.Join(lines[2:], ""))); err == nil || !strings.Contains(err.Error(), "mismatch") {
+		t.Fatalf("expected count mismatch error, got %v", err)
+	}
+}
+
+func TestReader_Checksum(t *testing.T) {
+	data := string(exportProfiles(t, "u1"))
+
+	tampered := strings.Replace(data, "nick-u1", "nick-u2", 1)
+	if _, err := readAll([]byte(tampered)); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected checksum mismatch error, got %v", err)
+	}
+
+	record := &Record{Type: EntityProfile, Key: "u1", Data: []byte(`{}`)}
+	if record.Verify() {
+		t.Fatal("record without checksum should not pass verification")
+	}
+}
@@ -0,0 +1,75 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 18:00
+ * @Desc: 增量备份状态
+ */
+
+package backup
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+)
+
+type (
+	// state 增量备份状态，记录上次备份时各群组的变更时间与最后一条消息的序列号
+	state struct {
+		Version   int                    `json:"version"`
+		UpdatedAt int64                  `json:"updatedAt"`
+		Groups    map[string]*groupState `json:"groups"`
+	}
+
+	groupState struct {
+		LastInfoTime int64 `json:"lastInfoTime"` // 上次备份时群资料的最后变更时间
+		LastMsgTime  int64 `json:"lastMsgTime"`  // 上次备份时群内最后一条消息的时间
+		LastMsgSeq   int   `json:"lastMsgSeq"`   // 上次备份的最后一条消息的序列号
+	}
+)
+
+// loadState 加载增量备份状态，文件不存在时返回空状态
+func loadState(path string) (s *state, err error) {
+	s = &state{Version: Version, Groups: make(map[string]*groupState)}
+
+	if path == "" {
+		return
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+
+	if err = json.Unmarshal(data, s); err != nil {
+		return
+	}
+
+	if s.Groups == nil {
+		s.Groups = make(map[string]*groupState)
+	}
+
+	return
+}
+
+// save 保存增量备份状态，先写入临时文件再替换，避免写入中断导致状态损坏
+func (s *state) save(path string) error {
+	if path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err = ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
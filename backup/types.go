@@ -0,0 +1,165 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 18:00
+ * @Desc: 备份数据结构
+ */
+
+package backup
+
+import (
+	"encoding/json"
+
+	"github.com/default-yarns/tencent-im/group"
+	"github.com/default-yarns/tencent-im/internal/types"
+	"github.com/default-yarns/tencent-im/profile"
+	"github.com/default-yarns/tencent-im/recentcontact"
+	"github.com/default-yarns/tencent-im/sns"
+)
+
+// Version 备份文件格式版本
+const Version = 1
+
+// Entity 备份实体类型
+type Entity string
+
+const (
+	EntityHeader       Entity = "header"        // 备份文件头
+	EntityGroup        Entity = "group"         // 群组
+	EntityMember       Entity = "member"        // 群成员
+	EntityGroupMessage Entity = "group_message" // 群消息
+	EntityProfile      Entity = "profile"       // 用户资料
+	EntityFriend       Entity = "friend"        // 好友
+	EntityBlacklist    Entity = "blacklist"     // 黑名单
+	EntitySession      Entity = "session"       // 会话
+	EntityFooter       Entity = "footer"        // 备份文件尾
+)
+
+// 默认备份的实体类型
+var defaultEntities = []Entity{
+	EntityGroup,
+	EntityMember,
+	EntityGroupMessage,
+	EntityProfile,
+	EntityFriend,
+	EntityBlacklist,
+	EntitySession,
+}
+
+type (
+	// Client 备份所需的接口集合，IM 实例即实现了该接口
+	Client interface {
+		Profile() profile.API
+		SNS() sns.API
+		Group() group.API
+		RecentContact() recentcontact.API
+	}
+
+	// Options 备份配置
+	Options struct {
+		UserIds      []string                        // （选填）需要备份资料、好友、黑名单和会话的账号列表，即时通信 IM 不提供拉取全部账号的接口，需由业务方提供
+		Entities     []Entity                        // （选填）需要备份的实体类型，默认为全部类型
+		GroupType    group.Type                      // （选填）仅备份指定类型的群组，默认为全部类型
+		ProfileAttrs []string                        // （选填）需要备份的资料字段，默认为全部标配资料字段
+		StateFile    string                          // （选填）增量备份状态文件路径，为空时进行全量备份
+		OnProgress   func(entity Entity, key string) // （选填）备份进度通知，每写入一条记录通知一次
+	}
+
+	// Record 备份记录，备份文件中的每一行为一条记录
+	Record struct {
+		Type     Entity          `json:"type"`               // 实体类型
+		Key      string          `json:"key"`                // 实体标识
+		Checksum string          `json:"checksum,omitempty"` // 实体数据的 SHA256 校验值
+		Data     json.RawMessage `json:"data"`               // 实体数据
+	}
+
+	// Header 备份文件头
+	Header struct {
+		Version     int      `json:"version"`     // 备份文件格式版本
+		CreatedAt   int64    `json:"createdAt"`   // 备份时间
+		Incremental bool     `json:"incremental"` // 是否为增量备份
+		Entities    []Entity `json:"entities"`    // 备份的实体类型
+	}
+
+	// Footer 备份文件尾
+	Footer struct {
+		Counts map[Entity]int `json:"counts"` // 各实体类型的记录数
+	}
+
+	// Summary 备份结果
+	Summary struct {
+		Counts        map[Entity]int // 各实体类型的记录数
+		SkippedGroups int            // 增量备份中资料未变更而跳过的群组数
+	}
+
+	// GroupData 群组数据
+	GroupData struct {
+		GroupId         string                 `json:"groupId"`
+		Type            string                 `json:"type"`
+		Name            string                 `json:"name"`
+		Owner           string                 `json:"owner"`
+		Introduction    string                 `json:"introduction,omitempty"`
+		Notification    string                 `json:"notification,omitempty"`
+		Avatar          string                 `json:"avatar,omitempty"`
+		MaxMemberNum    uint                   `json:"maxMemberNum"`
+		MemberNum       uint                   `json:"memberNum"`
+		ApplyJoinOption string                 `json:"applyJoinOption,omitempty"`
+		ShutUpStatus    string                 `json:"shutUpStatus,omitempty"`
+		CreateTime      int64                  `json:"createTime"`
+		LastInfoTime    int64                  `json:"lastInfoTime"`
+		LastMsgTime     int64                  `json:"lastMsgTime"`
+		NextMsgSeq      int                    `json:"nextMsgSeq"`
+		CustomData      map[string]interface{} `json:"customData,omitempty"`
+	}
+
+	// MemberData 群成员数据
+	MemberData struct {
+		GroupId      string                 `json:"groupId"`
+		UserId       string                 `json:"userId"`
+		Role         string                 `json:"role"`
+		NameCard     string                 `json:"nameCard,omitempty"`
+		JoinTime     int64                  `json:"joinTime"`
+		MsgSeq       int                    `json:"msgSeq"`
+		MsgFlag      string                 `json:"msgFlag,omitempty"`
+		ShutUpUntil  int64                  `json:"shutUpUntil"`
+		UnreadMsgNum int                    `json:"unreadMsgNum"`
+		CustomData   map[string]interface{} `json:"customData,omitempty"`
+	}
+
+	// GroupMessageData 群消息数据
+	GroupMessageData struct {
+		GroupId   string           `json:"groupId"`
+		MsgSeq    int              `json:"msgSeq"`
+		Sender    string           `json:"sender"`
+		MsgTime   int64            `json:"msgTime"`
+		MsgRandom uint32           `json:"msgRandom"`
+		Status    int              `json:"status"`
+		MsgBody   []*types.MsgBody `json:"msgBody"`
+	}
+
+	// ProfileData 用户资料数据
+	ProfileData struct {
+		UserId string                 `json:"userId"`
+		Attrs  map[string]interface{} `json:"attrs"`
+	}
+
+	// FriendData 好友数据
+	FriendData struct {
+		UserId       string                 `json:"userId"`
+		FriendUserId string                 `json:"friendUserId"`
+		Attrs        map[string]interface{} `json:"attrs"`
+	}
+
+	// BlacklistData 黑名单数据
+	BlacklistData struct {
+		UserId        string `json:"userId"`
+		BlackedUserId string `json:"blackedUserId"`
+		Time          int    `json:"time"`
+	}
+
+	// SessionData 会话数据
+	SessionData struct {
+		UserId  string                     `json:"userId"`
+		Session *recentcontact.SessionItem `json:"session"`
+	}
+)
@@ -0,0 +1,254 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 21:00
+ * @Desc: 回调来源IP白名单
+ */
+
+package callback
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultIPRefreshInterval = time.Hour // 默认的IP列表刷新间隔
+
+var errForbiddenIP = errors.New("forbidden callback source ip")
+
+type (
+	// IPListProvider 回调服务器IP列表提供者，operation.API 即实现了该接口
+	IPListProvider interface {
+		// GetIPList 获取服务器IP地址
+		GetIPList() (ips []string, err error)
+	}
+
+	// AllowlistOptions 来源IP白名单配置
+	AllowlistOptions struct {
+		RefreshInterval time.Duration   // （选填）IP列表的刷新间隔，默认为1小时，刷新失败时继续使用上次的列表
+		TrustedProxies  []string        // （选填）可信代理的IP或网段，仅来自可信代理的请求才会解析代理请求头
+		ProxyHeaders    []string        // （选填）代理请求头，默认为 X-Forwarded-For、X-Real-IP
+		ExtraIPs        []string        // （选填）额外允许的IP或网段
+		OnRefreshError  func(err error) // （选填）刷新IP列表失败时的通知
+	}
+
+	// ipAllowlist 来源IP白名单
+	ipAllowlist struct {
+		provider IPListProvider
+		opt      AllowlistOptions
+		trusted  []*net.IPNet
+		extra    []*net.IPNet
+		mu       sync.RWMutex
+		allowed  []*net.IPNet
+		stop     chan struct{}
+		once     sync.Once
+	}
+)
+
+// EnableIPAllowlist 开启来源IP白名单，仅允许来自即时通信 IM 回调服务器的请求
+// IP列表通过 provider 获取并缓存，按刷新间隔定期更新；首次获取失败时返回错误
+func (c *callback) EnableIPAllowlist(provider IPListProvider, opt ...*AllowlistOptions) (err error) {
+	if provider == nil {
+		return errors.New("the ip list provider is required")
+	}
+
+	o := AllowlistOptions{}
+	if len(opt) > 0 && opt[0] != nil {
+		o = *opt[0]
+	}
+
+	if o.RefreshInterval <= 0 {
+		o.RefreshInterval = defaultIPRefreshInterval
+	}
+
+	if len(o.ProxyHeaders) == 0 {
+		o.ProxyHeaders = []string{"X-Forwarded-For", "X-Real-IP"}
+	}
+
+	l := &ipAllowlist{provider: provider, opt: o, stop: make(chan struct{})}
+
+	if l.trusted, err = parseIPNets(o.TrustedProxies); err != nil {
+		return
+	}
+
+	if l.extra, err = parseIPNets(o.ExtraIPs); err != nil {
+		return
+	}
+
+	if err = l.refresh(); err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	prev := c.allowlist
+	c.allowlist = l
+	c.mu.Unlock()
+
+	if prev != nil {
+		prev.close()
+	}
+
+	go l.watch()
+
+	return
+}
+
+// checkSourceIP 校验请求的来源IP，未开启白名单时不校验
+func (c *callback) checkSourceIP(r *http.Request) error {
+	c.mu.RLock()
+	l := c.allowlist
+	c.mu.RUnlock()
+
+	if l == nil {
+		return nil
+	}
+
+	if ip := l.clientIP(r); ip == nil || !l.allow(ip) {
+		return errForbiddenIP
+	}
+
+	return nil
+}
+
+// refresh 刷新IP列表
+func (l *ipAllowlist) refresh() error {
+	ips, err := l.provider.GetIPList()
+	if err != nil {
+		return err
+	}
+
+	allowed, err := parseIPNets(ips)
+	if err != nil {
+		return err
+	}
+
+	if len(allowed) == 0 {
+		return errors.New("the ip list is empty")
+	}
+
+	l.mu.Lock()
+	l.allowed = allowed
+	l.mu.Unlock()
+
+	return nil
+}
+
+// watch 定期刷新IP列表
+func (l *ipAllowlist) watch() {
+	ticker := time.NewTicker(l.opt.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := l.refresh(); err != nil && l.opt.OnRefreshError != nil {
+				l.opt.OnRefreshError(err)
+			}
+		case <-l.stop:
+			return
+		}
+	}
+}
+
+// close 停止刷新IP列表
+func (l *ipAllowlist) close() {
+	l.once.Do(func() {
+		close(l.stop)
+	})
+}
+
+// allow 判断IP是否在白名单中
+func (l *ipAllowlist) allow(ip net.IP) bool {
+	if containsIP(l.extra, ip) {
+		return true
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return containsIP(l.allowed, ip)
+}
+
+// clientIP 获取请求的来源IP
+// 请求直接来自可信代理时，从右向左解析代理请求头，第一个不属于可信代理的地址即为来源IP
+func (l *ipAllowlist) clientIP(r *http.Request) net.IP {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	ip := net.ParseIP(strings.TrimSpace(host))
+	if ip == nil || !containsIP(l.trusted, ip) {
+		return ip
+	}
+
+	for _, header := range l.opt.ProxyHeaders {
+		value := r.Header.Get(header)
+		if value == "" {
+			continue
+		}
+
+		addrs := strings.Split(value, ",")
+		for i := len(addrs) - 1; i >= 0; i-- {
+			addr := net.ParseIP(strings.TrimSpace(addrs[i]))
+			if addr == nil {
+				return nil
+			}
+
+			if !containsIP(l.trusted, addr) {
+				return addr
+			}
+		}
+	}
+
+	return ip
+}
+
+// parseIPNets 解析IP或网段列表
+func parseIPNets(items []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(items))
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		if strings.Contains(item, "/") {
+			_, n, err := net.ParseCIDR(item)
+			if err != nil {
+				return nil, err
+			}
+			nets = append(nets, n)
+			continue
+		}
+
+		ip := net.ParseIP(item)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid ip address: %s", item)
+		}
+
+		if v4 := ip.To4(); v4 != nil {
+			nets = append(nets, &net.IPNet{IP: v4, Mask: net.CIDRMask(32, 32)})
+		} else {
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)})
+		}
+	}
+
+	return nets, nil
+}
+
+// containsIP 判断IP是否属于任一网段
+func containsIP(nets []*net.IPNet, ip net.IP) bool {
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,99 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/19 13:00
+ * @Desc: 回调来源IP白名单测试
+ */
+
+package callback
+
+import (
+	"context"
+	"net"
+	"net/http/httptest"
+	"testing"
+)
+
+type stubIPListProvider []string
+
+func (p stubIPListProvider) GetIPList() ([]string, error) {
+	return p, nil
+}
+
+func TestAllowlist_FrozenAfterShutdown(t *testing.T) {
+	c := newTestCallback()
+	if err := c.EnableIPAllowlist(stubIPListProvider{"10.0.0.1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest("POST", "/callback", nil)
+	r.RemoteAddr = "10.0.0.2:1234"
+	if err := c.checkSourceIP(r); err != errForbiddenIP {
+		t.Fatalf("allowlist should still apply after shutdown, got %v", err)
+	}
+
+	r.RemoteAddr = "10.0.0.1:1234"
+	if err := c.checkSourceIP(r); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseIPNets(t *testing.T) {
+	nets, err := parseIPNets([]string{"10.0.0.1", " 192.168.0.0/16 ", "", "::1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(nets) != 3 {
+		t.Fatalf("expected 3 nets, got %d", len(nets))
+	}
+
+	for ip, want := range map[string]bool{"10.0.0.1": true, "10.0.0.2": false, "192.168.3.4": true, "::1": true, "::2": false} {
+		if got := containsIP(nets, net.ParseIP(ip)); got != want {
+			t.Fatalf("containsIP(%s) = %v, want %v", ip, got, want)
+		}
+	}
+
+	for _, item := range []string{"10.0.0", "10.0.0.0/33"} {
+		if _, err = parseIPNets([]string{item}); err == nil {
+			t.Fatalf("%s should be invalid", item)
+		}
+	}
+}
+
+func TestAllowlist_ClientIP(t *testing.T) {
+	c := newTestCallback()
+	if err := c.EnableIPAllowlist(stubIPListProvider{"1.1.1.1"}, &AllowlistOptions{TrustedProxies: []string{"10.0.0.0/8"}}); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Shutdown(context.Background())
+
+	cases := []struct {
+		remoteAddr string
+		forwarded  string
+		allowed    bool
+	}{
+		{"1.1.1.1:80", "", true},
+		{"2.2.2.2:80", "1.1.1.1", false},           // 非可信代理的请求不解析代理请求头
+		{"10.0.0.1:80", "1.1.1.1", true},           // 可信代理转发的请求
+		{"10.0.0.1:80", "1.1.1.1, 2.2.2.2", false}, // 从右向左第一个非可信代理地址为来源IP
+		{"10.0.0.1:80", "1.1.1.1, 10.0.0.2", true},
+		{"10.0.0.1:80", "bad", false},
+	}
+
+	for _, item := range cases {
+		r := httptest.NewRequest("POST", "/callback", nil)
+		r.RemoteAddr = item.remoteAddr
+		if item.forwarded != "" {
+			r.Header.Set("X-Forwarded-For", item.forwarded)
+		}
+
+		if err := c.checkSourceIP(r); (err == nil) != item.allowed {
+			t.Fatalf("%s via %q: allowed = %v, want %v", item.forwarded, item.remoteAddr, err == nil, item.allowed)
+		}
+	}
+}
@@ -0,0 +1,337 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 23:00
+ * @Desc: 回调归档与重放
+ */
+
+package callback
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const defaultArchiveFile = "callback-archive.jsonl" // 默认的归档文件
+
+var ErrArchiveDisabled = errors.New("callback archive is not enabled")
+
+type (
+	// Record 归档的回调记录，保存回调命令与原始请求体，读取请求体请使用 GetBody
+	Record struct {
+		Time    int64           `json:"time"`              // 接收回调的时间，单位为毫秒
+		Command string          `json:"command"`           // 回调命令
+		Body    json.RawMessage `json:"body,omitempty"`    // 原始请求体，为合法的 JSON 时保存在该字段
+		RawBody []byte          `json:"rawBody,omitempty"` // 非 JSON 格式的原始请求体，以 base64 编码保存
+	}
+
+	// ArchiveStore 回调归档存储，可替换为数据库、对象存储等
+	ArchiveStore interface {
+		// Append 追加回调记录
+		Append(record *Record) error
+		// Scan 按归档顺序遍历回调记录，fn 返回错误时停止遍历并返回该错误
+		Scan(ctx context.Context, fn func(record *Record) error) error
+		// Close 关闭存储
+		Close() error
+	}
+
+	// ArchiveOptions 归档配置
+	ArchiveOptions struct {
+		Store   ArchiveStore    // （选填）归档存储，默认为 Path 指定的 JSONL 文件
+		Path    string          // （选填）默认存储的文件路径，默认为当前目录下的 callback-archive.jsonl
+		OnError func(err error) // （选填）归档失败时的通知，归档失败不影响回调处理
+	}
+
+	// ReplayOptions 重放配置
+	ReplayOptions struct {
+		Store    ArchiveStore                                      // （选填）重放的归档存储，默认为开启归档时的存储
+		Start    time.Time                                         // （选填）重放的开始时间（含），为零值时不限制
+		End      time.Time                                         // （选填）重放的结束时间（不含），为零值时不限制
+		Commands []string                                          // （选填）仅重放指定的回调命令
+		Events   []Event                                           // （选填）仅重放指定的回调事件
+		Filter   func(record *Record) bool                         // （选填）自定义过滤，返回 false 时跳过该记录
+		OnResult func(record *Record, resp interface{}, err error) // （选填）每条记录重放完成后的通知，err 为解析失败、处理器应答失败或 panic 的原因
+	}
+
+	// ReplayResult 重放结果
+	ReplayResult struct {
+		Total     int // 重放的记录数
+		Succeeded int // 处理成功的记录数
+		Failed    int // 处理失败的记录数
+	}
+
+	// archiver 回调归档器
+	archiver struct {
+		opt ArchiveOptions
+	}
+
+	// fileArchiveStore JSONL 文件归档存储，每行一条回调记录
+	fileArchiveStore struct {
+		mu   sync.Mutex
+		path string
+		file *os.File
+	}
+)
+
+// NewFileArchiveStore 创建 JSONL 文件归档存储，文件不存在时自动创建
+func NewFileArchiveStore(path string) (ArchiveStore, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, err
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileArchiveStore{path: path, file: file}, nil
+}
+
+// EnableArchive 开启回调归档，通过校验并解析成功的回调将在处理前记录原始命令、请求体与接收时间，
+// 重复投递的回调不再归档；归档的回调可通过 Replay 重新交由已注册的处理器处理
+func (c *callback) EnableArchive(opt ...*ArchiveOptions) (err error) {
+	o := ArchiveOptions{}
+	if len(opt) > 0 && opt[0] != nil {
+		o = *opt[0]
+	}
+
+	if o.Store == nil {
+		if o.Path == "" {
+			o.Path = defaultArchiveFile
+		}
+
+		if o.Store, err = NewFileArchiveStore(o.Path); err != nil {
+			return
+		}
+	}
+
+	c.mu.Lock()
+	prev := c.archive
+	c.archive = &archiver{opt: o}
+	c.mu.Unlock()
+
+	if prev != nil {
+		_ = prev.opt.Store.Close()
+	}
+
+	return
+}
+
+// Replay 重放归档的回调，按归档顺序同步交由已注册的中间件与处理器处理，不经过 HTTP、不校验签名也不去重；
+// 单条记录处理失败不影响后续记录，上下文结束时停止重放并返回上下文的错误
+func (c *callback) Replay(ctx context.Context, opt ...*ReplayOptions) (ret *ReplayResult, err error) {
+	o := ReplayOptions{}
+	if len(opt) > 0 && opt[0] != nil {
+		o = *opt[0]
+	}
+
+	if o.Store == nil {
+		c.mu.RLock()
+		a := c.archive
+		c.mu.RUnlock()
+
+		if a == nil {
+			return nil, ErrArchiveDisabled
+		}
+
+		o.Store = a.opt.Store
+	}
+
+	commands := toSet(o.Commands)
+	events := make(map[Event]bool, len(o.Events))
+	for _, event := range o.Events {
+		events[event] = true
+	}
+
+	ret = &ReplayResult{}
+	ctx = withReplay(ctx)
+
+	err = o.Store.Scan(ctx, func(record *Record) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if !o.Start.IsZero() && record.Time < toMillis(o.Start) {
+			return nil
+		}
+
+		if !o.End.IsZero() && record.Time >= toMillis(o.End) {
+			return nil
+		}
+
+		if len(commands) > 0 && !commands[record.Command] {
+			return nil
+		}
+
+		if o.Filter != nil && !o.Filter(record) {
+			return nil
+		}
+
+		event, data, err := c.parseCommand(record.Command, record.GetBody())
+		if err == nil && len(events) > 0 && !events[event] {
+			return nil
+		}
+
+		var resp interface{}
+		if err == nil {
+			resp, err = c.replay(ctx, record.Command, event, data)
+		}
+
+		ret.Total++
+		if err != nil {
+			ret.Failed++
+		} else {
+			ret.Succeeded++
+		}
+
+		if o.OnResult != nil {
+			o.OnResult(record, resp, err)
+		}
+
+		return nil
+	})
+
+	return
+}
+
+// replay 重放单条回调，处理器应答失败或发生 panic 时返回错误
+func (c *callback) replay(ctx context.Context, command string, event Event, data interface{}) (resp interface{}, err error) {
+	rec := &recordAck{}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("callback handler panic: %v", r)
+		}
+	}()
+
+	c.dispatch(ctx, rec, command, event, data)
+
+	if isVetoed(rec.resp) {
+		if base := baseRespOf(rec.resp); base.ErrorInfo != "" {
+			return rec.resp, errors.New(base.ErrorInfo)
+		}
+		return rec.resp, errors.New("callback handler failed")
+	}
+
+	return rec.resp, nil
+}
+
+// record 开启归档时记录回调
+func (c *callback) record(command string, body []byte) {
+	c.mu.RLock()
+	a := c.archive
+	c.mu.RUnlock()
+
+	if a == nil {
+		return
+	}
+
+	record := &Record{
+		Time:    toMillis(time.Now()),
+		Command: command,
+	}
+
+	// 未知回调的请求体可能不是合法的 JSON，无法作为 json.RawMessage 序列化
+	if json.Valid(body) {
+		record.Body = body
+	} else {
+		record.RawBody = body
+	}
+
+	err := a.opt.Store.Append(record)
+	if err != nil && a.opt.OnError != nil {
+		a.opt.OnError(err)
+	}
+}
+
+// GetBody 获取原始请求体
+func (r *Record) GetBody() []byte {
+	if len(r.RawBody) > 0 {
+		return r.RawBody
+	}
+
+	return r.Body
+}
+
+// Append 追加回调记录
+func (s *fileArchiveStore) Append(record *Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return os.ErrClosed
+	}
+
+	_, err = s.file.Write(append(line, '\n'))
+
+	return err
+}
+
+// Scan 按归档顺序遍历回调记录，跳过无法解析的行
+func (s *fileArchiveStore) Scan(ctx context.Context, fn func(record *Record) error) error {
+	file, err := os.Open(s.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			record := &Record{}
+			if e := json.Unmarshal(line, record); e == nil {
+				if e = fn(record); e != nil {
+					return e
+				}
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Close 关闭存储
+func (s *fileArchiveStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+
+	err := s.file.Close()
+	s.file = nil
+
+	return err
+}
+
+// toMillis 获取毫秒时间戳
+func toMillis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
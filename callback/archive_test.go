@@ -0,0 +1,105 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/19 15:00
+ * @Desc: 回调归档与重放测试
+ */
+
+package callback
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestArchive_Replay(t *testing.T) {
+	c := newTestCallback()
+
+	if _, err := c.Replay(context.Background()); err != ErrArchiveDisabled {
+		t.Fatalf("expected archive disabled, got %v", err)
+	}
+
+	if err := c.EnableArchive(&ArchiveOptions{Path: filepath.Join(t.TempDir(), "archive.jsonl")}); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Shutdown(context.Background())
+
+	serveCommand(t, c, commandAfterFriendAdd, `{"CallbackCommand":"Sns.CallbackFriendAdd","ClientCmd":"first"}`)
+	time.Sleep(5 * time.Millisecond)
+	mid := time.Now()
+	time.Sleep(5 * time.Millisecond)
+	serveCommand(t, c, commandAfterFriendAdd, `{"CallbackCommand":"Sns.CallbackFriendAdd","ClientCmd":"fail"}`)
+	serveCommand(t, c, commandAfterFriendDelete, `{"CallbackCommand":"Sns.CallbackFriendDelete"}`)
+
+	var (
+		cmds     []string
+		replayed = true
+	)
+	c.OnAfterFriendAdd(func(ctx context.Context, ack Ack, data *AfterFriendAdd) {
+		replayed = replayed && IsReplay(ctx)
+		cmds = append(cmds, data.ClientCmd)
+		if data.ClientCmd == "fail" {
+			_ = ack.AckFailure("failed")
+		}
+	})
+
+	ret, err := c.Replay(context.Background(), &ReplayOptions{Events: []Event{EventAfterFriendAdd}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 仅重放指定事件，按归档顺序处理，处理失败的记录单独计数
+	if ret.Total != 2 || ret.Succeeded != 1 || ret.Failed != 1 || len(cmds) != 2 || cmds[0] != "first" || !replayed {
+		t.Fatalf("unexpected replay result: %+v %v %v", ret, cmds, replayed)
+	}
+
+	cmds = nil
+	if ret, err = c.Replay(context.Background(), &ReplayOptions{Start: mid, Commands: []string{commandAfterFriendAdd}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if ret.Total != 1 || len(cmds) != 1 || cmds[0] != "fail" {
+		t.Fatalf("unexpected replay result with start time: %+v %v", ret, cmds)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err = c.Replay(ctx); err != context.Canceled {
+		t.Fatalf("expected context canceled, got %v", err)
+	}
+}
+
+func TestArchive_InvalidBody(t *testing.T) {
+	c := newTestCallback()
+
+	var archiveErr error
+	if err := c.EnableArchive(&ArchiveOptions{
+		Path:    filepath.Join(t.TempDir(), "archive.jsonl"),
+		OnError: func(err error) { archiveErr = err },
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Shutdown(context.Background())
+
+	// 未知回调的请求体不是合法的 JSON 时同样归档
+	serveCommand(t, c, "Some.NewCallback", "not json")
+	if archiveErr != nil {
+		t.Fatal(archiveErr)
+	}
+
+	var body string
+	c.OnUnknown(func(ctx context.Context, ack Ack, data *UnknownCommand) {
+		body = string(data.Body)
+	})
+
+	ret, err := c.Replay(context.Background(), &ReplayOptions{Commands: []string{"Some.NewCallback"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ret.Total != 1 || body != "not json" {
+		t.Fatalf("unexpected replay result: %+v %q", ret, body)
+	}
+}
@@ -0,0 +1,326 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 20:00
+ * @Desc: 回调异步处理
+ */
+
+package callback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	maxRetryInterval    = time.Minute // 重试间隔的上限
+	shutdownWorkerDelay = time.Second // 停止异步处理的上下文结束后，等待处理协程将任务放回队列的最长时间
+)
+
+type (
+	// AsyncOptions 异步处理配置
+	AsyncOptions struct {
+		Queue         Queue         // （选填）任务队列，默认为内存队列
+		QueueSize     int           // （选填）默认内存队列的容量，默认为1024
+		SpillDir      string        // （选填）默认内存队列的溢出目录，为空时队列满后退化为同步处理
+		Workers       int           // （选填）处理任务的协程数，默认为4
+		MaxRetries    int           // （选填）处理失败后的最大重试次数，默认为3，小于0时不重试
+		RetryInterval time.Duration // （选填）首次重试的间隔，之后每次重试间隔翻倍，默认为1秒
+		DeadLetter    Queue         // （选填）死信队列，超过最大重试次数的任务推入该队列，为空时丢弃
+	}
+
+	// drainer 支持排空的队列，排空模式下队列为空时取出任务不再阻塞
+	drainer interface {
+		drain()
+	}
+
+	// acker 支持确认的队列，任务处理完成、推入死信队列或放回队列后确认，未确认的任务由队列保留
+	acker interface {
+		ack(job *Job)
+	}
+
+	// asyncProcessor 异步处理器，After 类回调立即应答后推入队列，由协程池异步处理
+	asyncProcessor struct {
+		c       *callback
+		opt     AsyncOptions
+		queue   Queue
+		ctx     context.Context
+		cancel  context.CancelFunc
+		wg      sync.WaitGroup
+		stopped int32
+		dropped int32
+		seq     uint64
+	}
+)
+
+// EnableAsync 开启异步处理，After 类回调将立即应答成功，并推入队列由协程池异步执行处理器，
+// 处理器收到的上下文在停止异步处理且不再等待时取消；
+// 处理器应答失败或发生 panic 时按退避间隔重试，超过最大重试次数后推入死信队列；
+// Before 类回调及未知回调仍同步处理，入队失败时同样退化为同步处理
+func (c *callback) EnableAsync(opt ...*AsyncOptions) (err error) {
+	o := AsyncOptions{}
+	if len(opt) > 0 && opt[0] != nil {
+		o = *opt[0]
+	}
+
+	if o.Workers <= 0 {
+		o.Workers = 4
+	}
+
+	if o.MaxRetries == 0 {
+		o.MaxRetries = 3
+	}
+
+	if o.RetryInterval <= 0 {
+		o.RetryInterval = time.Second
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.async != nil {
+		return errors.New("async processing is already enabled")
+	}
+
+	queue := o.Queue
+	if queue == nil {
+		if queue, err = NewMemoryQueue(o.QueueSize, o.SpillDir); err != nil {
+			return
+		}
+	}
+
+	p := &asyncProcessor{c: c, opt: o, queue: queue}
+	p.ctx, p.cancel = context.WithCancel(context.Background())
+
+	for i := 0; i < o.Workers; i++ {
+		p.wg.Add(1)
+		go p.work()
+	}
+
+	c.async = p
+
+	return
+}
+
+// Shutdown 停止异步处理与来源IP白名单的刷新，并关闭归档存储
+// 来源IP白名单停止刷新后仍然生效，固定使用最后一次获取的IP列表，避免关闭过程中仍在处理的请求跳过来源校验；
+// 停止接收新的异步任务，默认内存队列在上下文结束前继续处理队列中剩余的任务，自定义队列仅等待正在处理的任务完成，
+// 未处理的任务由队列自行持久化；上下文结束时取消处理器的上下文，并最多等待1秒使等待重试的任务放回队列后关闭队列，
+// 返回上下文的错误；默认内存队列未配置溢出目录或任务未能放回队列时返回 *DroppedError，记录丢弃的任务数
+func (c *callback) Shutdown(ctx context.Context) (err error) {
+	c.mu.Lock()
+	p := c.async
+	c.async = nil
+	if c.allowlist != nil {
+		// 仅停止刷新，保留白名单继续校验来源IP
+		c.allowlist.close()
+	}
+	a := c.archive
+	c.archive = nil
+	c.mu.Unlock()
+
+	if a != nil {
+		defer a.opt.Store.Close()
+	}
+
+	if p == nil {
+		return
+	}
+
+	atomic.StoreInt32(&p.stopped, 1)
+	if d, ok := p.queue.(drainer); ok {
+		d.drain()
+	} else {
+		p.cancel()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+		p.cancel()
+
+		// 取消后等待处理协程退出，避免等待重试的任务在队列关闭后才放回队列
+		timer := time.NewTimer(shutdownWorkerDelay)
+		select {
+		case <-done:
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+	p.cancel()
+
+	e := p.queue.Close()
+	if dropped := int(atomic.LoadInt32(&p.dropped)); dropped > 0 {
+		var de *DroppedError
+		if errors.As(e, &de) {
+			de.Dropped += dropped
+		} else if e == nil {
+			e = &DroppedError{Dropped: dropped}
+		}
+	}
+
+	if e != nil {
+		var de *DroppedError
+		if errors.As(e, &de) && de.Err == nil {
+			de.Err = err
+		}
+		err = e
+	}
+
+	return
+}
+
+// enqueue 将回调推入队列，不需要异步处理或入队失败时返回 false
+func (p *asyncProcessor) enqueue(event Event, command string, body []byte) bool {
+	if !isAsyncEvent(event) || atomic.LoadInt32(&p.stopped) == 1 {
+		return false
+	}
+
+	now := time.Now()
+	job := &Job{
+		Id:         strconv.FormatInt(now.UnixNano(), 36) + "-" + strconv.FormatUint(atomic.AddUint64(&p.seq, 1), 36),
+		Command:    command,
+		Body:       body,
+		EnqueuedAt: now.UnixNano() / int64(time.Millisecond),
+	}
+
+	return p.queue.Push(job) == nil
+}
+
+// work 循环取出任务并处理
+func (p *asyncProcessor) work() {
+	defer p.wg.Done()
+
+	// 处理器的上下文取消后不再取出新的任务
+	for p.ctx.Err() == nil {
+		job, err := p.queue.Pop(p.ctx)
+		if err != nil {
+			if p.ctx.Err() != nil || err == ErrQueueClosed {
+				return
+			}
+			continue
+		}
+
+		p.process(job)
+	}
+}
+
+// process 处理任务，失败时按退避间隔重试
+func (p *asyncProcessor) process(job *Job) {
+	for {
+		err := p.handle(job)
+		if err == nil {
+			p.ack(job)
+			return
+		}
+
+		job.Attempts++
+		job.LastError = err.Error()
+
+		if p.opt.MaxRetries < 0 || job.Attempts > p.opt.MaxRetries {
+			if p.opt.DeadLetter != nil {
+				_ = p.opt.DeadLetter.Push(job)
+			}
+			p.ack(job)
+			return
+		}
+
+		timer := time.NewTimer(p.backoff(job.Attempts))
+		select {
+		case <-timer.C:
+		case <-p.ctx.Done():
+			// 停止处理时将等待重试的任务放回队列，由队列持久化；放回失败的任务计为丢弃
+			timer.Stop()
+			if err = p.queue.Push(job); err != nil {
+				atomic.AddInt32(&p.dropped, 1)
+				return
+			}
+			p.ack(job)
+			return
+		}
+	}
+}
+
+// ack 确认任务已处理完成
+func (p *asyncProcessor) ack(job *Job) {
+	if a, ok := p.queue.(acker); ok {
+		a.ack(job)
+	}
+}
+
+// handle 解析并执行任务，处理器应答失败或发生 panic 时返回错误
+func (p *asyncProcessor) handle(job *Job) (err error) {
+	event, data, err := p.c.parseCommand(job.Command, job.Body)
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("callback handler panic: %v", r)
+		}
+	}()
+
+	rec := &recordAck{}
+	p.c.dispatch(p.ctx, rec, job.Command, event, data)
+
+	if isVetoed(rec.resp) {
+		if base := baseRespOf(rec.resp); base.ErrorInfo != "" {
+			return errors.New(base.ErrorInfo)
+		}
+		return errors.New("callback handler failed")
+	}
+
+	return
+}
+
+// backoff 计算第 attempts 次重试的间隔
+func (p *asyncProcessor) backoff(attempts int) time.Duration {
+	interval := p.opt.RetryInterval
+	for i := 1; i < attempts && interval < maxRetryInterval; i++ {
+		interval *= 2
+	}
+
+	if interval > maxRetryInterval {
+		interval = maxRetryInterval
+	}
+
+	return interval
+}
+
+// isAsyncEvent 判断事件是否可以异步处理，需要业务应答的 Before 类回调与未知回调只能同步处理
+func isAsyncEvent(event Event) bool {
+	return event != EventUnknown && !isBeforeEvent(event)
+}
+
+// isBeforeEvent 判断是否为 Before 类回调
+func isBeforeEvent(event Event) bool {
+	switch event {
+	case EventBeforeFriendAdd,
+		EventBeforeFriendResponse,
+		EventBeforePrivateMessageSend,
+		EventBeforeGroupCreate,
+		EventBeforeApplyJoinGroup,
+		EventBeforeInviteJoinGroup,
+		EventBeforeGroupMessageSend,
+		EventBeforeTopicCreate,
+		EventBeforeConversationGroupCreate,
+		EventBeforeConversationGroupUpdate,
+		EventBeforeConversationGroupDelete:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,119 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/19 12:00
+ * @Desc: 回调异步处理测试
+ */
+
+package callback
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func enqueueAfterFriendAdd(t *testing.T, c *callback, n int) {
+	for i := 0; i < n; i++ {
+		if !c.async.enqueue(EventAfterFriendAdd, commandAfterFriendAdd, []byte(`{"CallbackCommand":"Sns.CallbackFriendAdd"}`)) {
+			t.Fatal("enqueue failed")
+		}
+	}
+}
+
+func TestShutdown_Drain(t *testing.T) {
+	c := newTestCallback()
+
+	var handled int32
+	c.OnAfterFriendAdd(func(ctx context.Context, ack Ack, data *AfterFriendAdd) {
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&handled, 1)
+	})
+
+	if err := c.EnableAsync(&AsyncOptions{Workers: 1}); err != nil {
+		t.Fatal(err)
+	}
+	enqueueAfterFriendAdd(t, c, 5)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := c.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := atomic.LoadInt32(&handled); n != 5 {
+		t.Fatalf("expected 5 jobs handled, got %d", n)
+	}
+}
+
+func TestShutdown_DropOnTimeout(t *testing.T) {
+	c := newTestCallback()
+
+	started := make(chan struct{}, 1)
+	cancelled := make(chan struct{})
+	c.OnAfterFriendAdd(func(ctx context.Context, ack Ack, data *AfterFriendAdd) {
+		started <- struct{}{}
+		<-ctx.Done()
+		close(cancelled)
+	})
+
+	if err := c.EnableAsync(&AsyncOptions{Workers: 1, MaxRetries: -1}); err != nil {
+		t.Fatal(err)
+	}
+	enqueueAfterFriendAdd(t, c, 5)
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := c.Shutdown(ctx)
+
+	var de *DroppedError
+	if !errors.As(err, &de) || de.Dropped != 4 || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected 4 dropped jobs after deadline, got %v", err)
+	}
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("handler context was not cancelled")
+	}
+}
+
+func TestShutdown_RequeueRetrying(t *testing.T) {
+	c := newTestCallback()
+
+	failed := make(chan struct{}, 1)
+	c.OnAfterFriendAdd(func(ctx context.Context, ack Ack, data *AfterFriendAdd) {
+		_ = ack.AckFailure("failed")
+		failed <- struct{}{}
+	})
+
+	dir := t.TempDir()
+	if err := c.EnableAsync(&AsyncOptions{Workers: 1, SpillDir: dir, RetryInterval: time.Hour}); err != nil {
+		t.Fatal(err)
+	}
+	enqueueAfterFriendAdd(t, c, 1)
+	<-failed
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := c.Shutdown(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+
+	// 等待重试的任务在队列关闭前放回队列并写入溢出文件
+	q, err := NewMemoryQueue(1, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q.Close()
+
+	if job, err := q.Pop(context.Background()); err != nil || job.Attempts != 1 || job.LastError != "failed" {
+		t.Fatalf("unexpected job after reopen: %+v %v", job, err)
+	}
+}
@@ -13,7 +13,6 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
-	"io/ioutil"
 	"net/http"
 	"strconv"
 	"sync"
@@ -21,28 +20,46 @@ import (
 )
 
 const (
-	commandStateChange               = "State.StateChange"
-	commandBeforeFriendAdd           = "Sns.CallbackPrevFriendAdd"
-	commandBeforeFriendResponse      = "Sns.CallbackPrevFriendResponse"
-	commandAfterFriendAdd            = "Sns.CallbackFriendAdd"
-	commandAfterFriendDelete         = "Sns.CallbackFriendDelete"
-	commandAfterBlacklistAdd         = "Sns.CallbackBlackListAdd"
-	commandAfterBlacklistDelete      = "Sns.CallbackBlackListDelete"
-	commandBeforePrivateMessageSend  = "C2C.CallbackBeforeSendMsg"
-	commandAfterPrivateMessageSend   = "C2C.CallbackAfterSendMsg"
-	commandAfterPrivateMessageReport = "C2C.CallbackAfterMsgReport"
-	commandAfterPrivateMessageRevoke = "C2C.CallbackAfterMsgWithDraw"
-	commandBeforeGroupCreate         = "Group.CallbackBeforeCreateGroup"
-	commandAfterGroupCreate          = "Group.CallbackAfterCreateGroup"
-	commandBeforeApplyJoinGroup      = "Group.CallbackBeforeApplyJoinGroup"
-	commandBeforeInviteJoinGroup     = "Group.CallbackBeforeInviteJoinGroup"
-	commandAfterNewMemberJoinGroup   = "Group.CallbackAfterNewMemberJoin"
-	commandAfterMemberExitGroup      = "Group.CallbackAfterMemberExit"
-	commandBeforeGroupMessageSend    = "Group.CallbackBeforeSendMsg"
-	commandAfterGroupMessageSend     = "Group.CallbackAfterSendMsg"
-	commandAfterGroupFull            = "Group.CallbackAfterGroupFull"
-	commandAfterGroupDestroyed       = "Group.CallbackAfterGroupDestroyed"
-	commandAfterGroupInfoChanged     = "Group.CallbackAfterGroupInfoChanged"
+	commandStateChange                        = "State.StateChange"
+	commandBeforeFriendAdd                    = "Sns.CallbackPrevFriendAdd"
+	commandBeforeFriendResponse               = "Sns.CallbackPrevFriendResponse"
+	commandAfterFriendAdd                     = "Sns.CallbackFriendAdd"
+	commandAfterFriendDelete                  = "Sns.CallbackFriendDelete"
+	commandAfterBlacklistAdd                  = "Sns.CallbackBlackListAdd"
+	commandAfterBlacklistDelete               = "Sns.CallbackBlackListDelete"
+	commandBeforePrivateMessageSend           = "C2C.CallbackBeforeSendMsg"
+	commandAfterPrivateMessageSend            = "C2C.CallbackAfterSendMsg"
+	commandAfterPrivateMessageReport          = "C2C.CallbackAfterMsgReport"
+	commandAfterPrivateMessageRevoke          = "C2C.CallbackAfterMsgWithDraw"
+	commandBeforeGroupCreate                  = "Group.CallbackBeforeCreateGroup"
+	commandAfterGroupCreate                   = "Group.CallbackAfterCreateGroup"
+	commandBeforeApplyJoinGroup               = "Group.CallbackBeforeApplyJoinGroup"
+	commandBeforeInviteJoinGroup              = "Group.CallbackBeforeInviteJoinGroup"
+	commandAfterNewMemberJoinGroup            = "Group.CallbackAfterNewMemberJoin"
+	commandAfterMemberExitGroup               = "Group.CallbackAfterMemberExit"
+	commandBeforeGroupMessageSend             = "Group.CallbackBeforeSendMsg"
+	commandAfterGroupMessageSend              = "Group.CallbackAfterSendMsg"
+	commandAfterGroupFull                     = "Group.CallbackAfterGroupFull"
+	commandAfterGroupDestroyed                = "Group.CallbackAfterGroupDestroyed"
+	commandAfterGroupInfoChanged              = "Group.CallbackAfterGroupInfoChanged"
+	commandAfterGroupMessageExtensionChange   = "Group.CallbackAfterMsgExtensionChange"
+	commandAfterProfileUpdate                 = "Profile.CallbackPortraitSet"
+	commandAfterPrivateMessageModify          = "C2C.CallbackAfterMsgModify"
+	commandAfterGroupMessageRevoke            = "Group.CallbackAfterRecallMsg"
+	commandAfterGroupMessageModify            = "Group.CallbackAfterMsgModify"
+	commandAfterGroupMemberRoleChange         = "Group.CallbackAfterMemberRoleChanged"
+	commandAfterGroupAttrChange               = "Group.CallbackAfterGroupAttrChanged"
+	commandBeforeTopicCreate                  = "Group.CallbackBeforeCreateTopic"
+	commandAfterTopicCreate                   = "Group.CallbackAfterCreateTopic"
+	commandAfterTopicDestroyed                = "Group.CallbackAfterTopicDestroyed"
+	commandAfterTopicInfoChanged              = "Group.CallbackAfterTopicInfoChanged"
+	commandBeforeConversationGroupCreate      = "Conversation.CallbackBeforeCreateConversationGroup"
+	commandAfterConversationGroupCreate       = "Conversation.CallbackAfterCreateConversationGroup"
+	commandBeforeConversationGroupUpdate      = "Conversation.CallbackBeforeUpdateConversationGroup"
+	commandAfterConversationGroupUpdate       = "Conversation.CallbackAfterUpdateConversationGroup"
+	commandBeforeConversationGroupDelete      = "Conversation.CallbackBeforeDeleteConversationGroup"
+	commandAfterConversationGroupDelete       = "Conversation.CallbackAfterDeleteConversationGroup"
+	commandAfterPrivateMessageExtensionChange = "C2C.CallbackAfterMsgExtensionChange"
 )
 
 const (
@@ -68,8 +85,29 @@ const (
 	EventAfterGroupFull
 	EventAfterGroupDestroyed
 	EventAfterGroupInfoChanged
+	EventAfterGroupMessageExtensionChange
+	EventAfterProfileUpdate
+	EventAfterPrivateMessageModify
+	EventAfterGroupMessageRevoke
+	EventAfterGroupMessageModify
+	EventAfterGroupMemberRoleChange
+	EventAfterGroupAttrChange
+	EventBeforeTopicCreate
+	EventAfterTopicCreate
+	EventAfterTopicDestroyed
+	EventAfterTopicInfoChanged
+	EventBeforeConversationGroupCreate
+	EventAfterConversationGroupCreate
+	EventBeforeConversationGroupUpdate
+	EventAfterConversationGroupUpdate
+	EventBeforeConversationGroupDelete
+	EventAfterConversationGroupDelete
+	EventAfterPrivateMessageExtensionChange
 )
 
+// EventUnknown 未知的回调命令，SDK 尚未支持的回调将以 *UnknownCommand 的形式透传给该事件的处理器
+const EventUnknown Event = -1
+
 const (
 	ackSuccessStatus = "OK"
 	ackFailureStatus = "FAIL"
@@ -94,17 +132,41 @@ type (
 	}
 
 	Callback interface {
-		// Register 注册事件
+		TypedRegistry
+		// Register 注册事件，同一事件可注册多个处理器，按注册顺序依次执行
 		Register(event Event, handler EventHandlerFunc)
-		// Listen 监听事件
+		// Use 注册中间件，中间件按注册顺序由外到内分别包裹每个处理器，未注册处理器的事件不执行中间件
+		Use(middlewares ...Middleware)
+		// EnableAsync 开启 After 类回调的异步处理
+		EnableAsync(opt ...*AsyncOptions) error
+		// Shutdown 停止异步处理与来源IP白名单的刷新，并关闭归档存储，来源IP白名单停止刷新后仍然生效
+		Shutdown(ctx context.Context) error
+		// EnableDedup 开启回调去重与重放防护
+		EnableDedup(opt ...*DedupOptions)
+		// EnableIPAllowlist 开启回调来源IP白名单
+		EnableIPAllowlist(provider IPListProvider, opt ...*AllowlistOptions) error
+		// EnableArchive 开启回调归档
+		EnableArchive(opt ...*ArchiveOptions) error
+		// Replay 重放归档的回调
+		Replay(ctx context.Context, opt ...*ReplayOptions) (*ReplayResult, error)
+		// Listen 监听事件，请求校验失败时以 200 状态码应答失败，不限制请求体大小
 		Listen(ctx context.Context, w http.ResponseWriter, r *http.Request)
+		// Handler 获取回调的 http.Handler，可配置请求体大小上限与处理超时时间
+		Handler(opt ...*HandlerOptions) http.Handler
+		// ServeHTTP 以默认配置处理回调请求，回调本身即可作为 http.Handler 使用
+		ServeHTTP(w http.ResponseWriter, r *http.Request)
 	}
 
 	callback struct {
-		appId    int
-		token    string
-		mu       sync.Mutex
-		handlers map[Event]EventHandlerFunc
+		appId       int
+		token       string
+		mu          sync.RWMutex
+		handlers    map[Event][]EventHandlerFunc
+		middlewares []Middleware
+		async       *asyncProcessor
+		dedup       *deduplicator
+		allowlist   *ipAllowlist
+		archive     *archiver
 	}
 
 	Ack interface {
@@ -117,14 +179,17 @@ type (
 	}
 
 	ack struct {
-		w http.ResponseWriter
+		w      http.ResponseWriter
+		mu     sync.Mutex
+		acked  bool
+		legacy bool // 兼容 Listen 的应答方式，请求校验失败时同样以 200 状态码应答
 	}
 )
 
 func NewCallback(appId int, token ...string) Callback {
 	ca := &callback{
 		appId:    appId,
-		handlers: make(map[Event]EventHandlerFunc),
+		handlers: make(map[Event][]EventHandlerFunc),
 	}
 	if len(token) > 0 {
 		ca.token = token[0]
@@ -161,69 +226,133 @@ func abs(x int64) int64 {
 	return x
 }
 
-// Register 注册事件
+// Register 注册事件，同一事件可注册多个处理器，按注册顺序依次执行
 func (c *callback) Register(event Event, handler EventHandlerFunc) {
 	c.mu.Lock()
-	c.handlers[event] = handler
+	c.handlers[event] = append(c.handlers[event], handler)
+	c.mu.Unlock()
+}
+
+// Use 注册中间件，中间件按注册顺序由外到内分别包裹每个处理器，未注册处理器的事件不执行中间件；
+// 中间件仅作用于被包裹的处理器，如 Recovery 捕获某个处理器的 panic 后应答失败，
+// After 类回调的后续处理器仍会执行，Before 类回调则视为该处理器否决了本次操作
+func (c *callback) Use(middlewares ...Middleware) {
+	c.mu.Lock()
+	c.middlewares = append(c.middlewares, middlewares...)
 	c.mu.Unlock()
 }
 
 // Listen 监听事件
+// 保持原有的应答方式：请求校验失败时以 200 状态码应答失败（ActionStatus 为 FAIL），不校验请求方法也不限制请求体大小；
+// 需要以对应的 HTTP 状态码应答或限制请求体大小时请使用 Handler
 func (c *callback) Listen(ctx context.Context, w http.ResponseWriter, r *http.Request) {
-	a := newAck(w)
+	c.serve(ctx, &ack{w: w, legacy: true}, r, 0)
+}
+
+// serve 处理回调请求，请求校验失败时以对应的 HTTP 状态码应答，回调处理结果以 200 状态码应答；
+// maxBodySize 小于等于0时不限制请求体大小
+func (c *callback) serve(ctx context.Context, a *ack, r *http.Request, maxBodySize int64) {
+	if !a.legacy && r.Method != http.MethodPost {
+		a.w.Header().Set("Allow", http.MethodPost)
+		_ = a.fail(http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	// 校验来源IP
+	if err := c.checkSourceIP(r); err != nil {
+		_ = a.fail(http.StatusForbidden, err.Error())
+		return
+	}
 	// 校验签名
 	if c.token != "" {
 		sign, ok := c.GetQuery(r, querySign)
 		if !ok {
-			_ = a.AckFailure("invalid sign")
+			_ = a.fail(http.StatusUnauthorized, "invalid sign")
 			return
 		}
 		requestTime, ok := c.GetQuery(r, querySignRequestTime)
 		if !ok {
-			_ = a.AckFailure("invalid request time")
+			_ = a.fail(http.StatusUnauthorized, "invalid request time")
 			return
 		}
 		requestTimeInt, err := strconv.ParseInt(requestTime, 10, 64)
 		if err != nil {
-			_ = a.AckFailure("parse request time err")
+			_ = a.fail(http.StatusUnauthorized, "parse request time err")
 			return
 		}
 		if err = c.signCheck(sign, requestTimeInt, c.token); err != nil {
-			_ = a.AckFailure(err.Error())
+			_ = a.fail(http.StatusUnauthorized, err.Error())
 			return
 		}
 	}
 	appId, ok := c.GetQuery(r, queryAppId)
 	if !ok || appId != strconv.Itoa(c.appId) {
-		_ = a.AckFailure("invalid sdk appId")
+		_ = a.fail(http.StatusForbidden, "invalid sdk appId")
 		return
 	}
 
 	command, ok := c.GetQuery(r, queryCommand)
 	if !ok {
-		_ = a.AckFailure("invalid callback command")
+		_ = a.fail(http.StatusBadRequest, "invalid callback command")
 		return
 	}
 
-	body, err := ioutil.ReadAll(r.Body)
-	_ = r.Body.Close()
+	body, err := readBody(r, maxBodySize)
 	if err != nil {
-		_ = a.AckFailure(err.Error())
+		if err == errBodyTooLarge {
+			_ = a.fail(http.StatusRequestEntityTooLarge, err.Error())
+		} else {
+			_ = a.fail(http.StatusBadRequest, err.Error())
+		}
 		return
 	}
 
-	if event, data, err := c.parseCommand(command, body); err != nil {
-		_ = a.AckFailure(err.Error())
-	} else {
-		if fn, ok := c.handlers[event]; ok {
-			fn(ctx, a, data)
-			return
+	event, data, err := c.parseCommand(command, body)
+	if err != nil {
+		_ = a.fail(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	keys, duplicated, err := c.deduplicate(r, command, body, event, data)
+	if err != nil {
+		if err == errReplayedRequest {
+			_ = a.fail(http.StatusConflict, err.Error())
 		} else {
-			_ = a.AckSuccess(ackSuccessCode)
+			_ = a.fail(http.StatusInternalServerError, err.Error())
 		}
+		return
+	}
+
+	if !duplicated {
+		c.record(command, body)
+	}
+
+	if duplicated || c.enqueue(event, command, body) {
+		_ = a.AckSuccess(ackSuccessCode)
+		return
+	}
+
+	if len(keys) == 0 {
+		c.dispatch(ctx, a, command, event, data)
+		return
+	}
+
+	// 同步处理失败时释放重放记录与去重记录，以便重试投递时再次处理
+	sa := &statusAck{ack: a}
+	c.dispatch(ctx, sa, command, event, data)
+	if isVetoed(sa.resp) {
+		c.release(keys...)
 	}
 }
 
+// enqueue 开启异步处理时将回调推入队列
+func (c *callback) enqueue(event Event, command string, body []byte) bool {
+	c.mu.RLock()
+	p := c.async
+	c.mu.RUnlock()
+
+	return p != nil && p.enqueue(event, command, body)
+}
+
 // parseCommand parse command and body package.
 func (c *callback) parseCommand(command string, body []byte) (event Event, data interface{}, err error) {
 	switch command {
@@ -293,8 +422,65 @@ func (c *callback) parseCommand(command string, body []byte) (event Event, data
 	case commandAfterGroupInfoChanged:
 		event = EventAfterGroupInfoChanged
 		data = &AfterGroupInfoChanged{}
+	case commandAfterGroupMessageExtensionChange:
+		event = EventAfterGroupMessageExtensionChange
+		data = &AfterGroupMessageExtensionChange{}
+	case commandAfterProfileUpdate:
+		event = EventAfterProfileUpdate
+		data = &AfterProfileUpdate{}
+	case commandAfterPrivateMessageModify:
+		event = EventAfterPrivateMessageModify
+		data = &AfterPrivateMessageModify{}
+	case commandAfterGroupMessageRevoke:
+		event = EventAfterGroupMessageRevoke
+		data = &AfterGroupMessageRevoke{}
+	case commandAfterGroupMessageModify:
+		event = EventAfterGroupMessageModify
+		data = &AfterGroupMessageModify{}
+	case commandAfterGroupMemberRoleChange:
+		event = EventAfterGroupMemberRoleChange
+		data = &AfterGroupMemberRoleChange{}
+	case commandAfterGroupAttrChange:
+		event = EventAfterGroupAttrChange
+		data = &AfterGroupAttrChange{}
+	case commandBeforeTopicCreate:
+		event = EventBeforeTopicCreate
+		data = &BeforeTopicCreate{}
+	case commandAfterTopicCreate:
+		event = EventAfterTopicCreate
+		data = &AfterTopicCreate{}
+	case commandAfterTopicDestroyed:
+		event = EventAfterTopicDestroyed
+		data = &AfterTopicDestroyed{}
+	case commandAfterTopicInfoChanged:
+		event = EventAfterTopicInfoChanged
+		data = &AfterTopicInfoChanged{}
+	case commandBeforeConversationGroupCreate:
+		event = EventBeforeConversationGroupCreate
+		data = &BeforeConversationGroupCreate{}
+	case commandAfterConversationGroupCreate:
+		event = EventAfterConversationGroupCreate
+		data = &AfterConversationGroupCreate{}
+	case commandBeforeConversationGroupUpdate:
+		event = EventBeforeConversationGroupUpdate
+		data = &BeforeConversationGroupUpdate{}
+	case commandAfterConversationGroupUpdate:
+		event = EventAfterConversationGroupUpdate
+		data = &AfterConversationGroupUpdate{}
+	case commandBeforeConversationGroupDelete:
+		event = EventBeforeConversationGroupDelete
+		data = &BeforeConversationGroupDelete{}
+	case commandAfterConversationGroupDelete:
+		event = EventAfterConversationGroupDelete
+		data = &AfterConversationGroupDelete{}
+	case commandAfterPrivateMessageExtensionChange:
+		event = EventAfterPrivateMessageExtensionChange
+		data = &AfterPrivateMessageExtensionChange{}
 	default:
-		return 0, nil, errors.New("invalid callback command")
+		if command == "" {
+			return 0, nil, errors.New("invalid callback command")
+		}
+		return EventUnknown, &UnknownCommand{CallbackCommand: command, Body: body}, nil
 	}
 
 	if err = json.Unmarshal(body, &data); err != nil {
@@ -314,19 +500,52 @@ func (c *callback) GetQuery(r *http.Request, key string) (string, bool) {
 }
 
 func newAck(w http.ResponseWriter) Ack {
-	return &ack{w}
+	return &ack{w: w}
 }
 
-// Ack 应答
+// Ack 应答，每个回调请求只能应答一次
 func (a *ack) Ack(resp interface{}) error {
+	return a.write(http.StatusOK, resp)
+}
+
+// fail 以指定的 HTTP 状态码应答失败，兼容 Listen 的应答方式时以 200 状态码应答
+func (a *ack) fail(status int, message string) error {
+	if a.legacy {
+		status = http.StatusOK
+	}
+
+	return a.write(status, failureResp(message))
+}
+
+// write 写入应答
+func (a *ack) write(status int, resp interface{}) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.acked {
+		return errAlreadyAcked
+	}
+	a.acked = true
+
 	b, _ := json.Marshal(resp)
-	a.w.WriteHeader(http.StatusOK)
+	a.w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	a.w.WriteHeader(status)
 	_, err := a.w.Write(b)
 	return err
 }
 
 // AckFailure 应答失败
 func (a *ack) AckFailure(message ...string) error {
+	return a.Ack(failureResp(message...))
+}
+
+// AckSuccess 应答成功
+func (a *ack) AckSuccess(code int, message ...string) error {
+	return a.Ack(successResp(code, message...))
+}
+
+// failureResp 构建失败应答
+func failureResp(message ...string) BaseResp {
 	resp := BaseResp{}
 	resp.ActionStatus = ackFailureStatus
 	resp.ErrorCode = ackFailureCode
@@ -334,11 +553,11 @@ func (a *ack) AckFailure(message ...string) error {
 		resp.ErrorInfo = message[0]
 	}
 
-	return a.Ack(resp)
+	return resp
 }
 
-// AckSuccess 应答成功
-func (a *ack) AckSuccess(code int, message ...string) error {
+// successResp 构建成功应答
+func successResp(code int, message ...string) BaseResp {
 	resp := BaseResp{}
 	resp.ActionStatus = ackSuccessStatus
 	resp.ErrorCode = code
@@ -346,5 +565,12 @@ func (a *ack) AckSuccess(code int, message ...string) error {
 		resp.ErrorInfo = message[0]
 	}
 
-	return a.Ack(resp)
+	return resp
+}
+
+// rejectResp 构建 Before 类回调的拒绝应答
+// 即时通信 IM 将 ActionStatus 为 FAIL 的应答视为回调失败并按默认规则放行，
+// 拒绝操作需以 ActionStatus 为 OK、ErrorCode 非0的应答表示
+func rejectResp(code int, reason string) BaseResp {
+	return successResp(code, reason)
 }
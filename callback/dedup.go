@@ -0,0 +1,242 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 20:30
+ * @Desc: 回调去重与重放防护
+ */
+
+package callback
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	replayTTL        = 2 * time.Minute  // 重放记录的有效期，需大于签名的有效期
+	defaultDedupTTL  = 10 * time.Minute // 默认的去重记录有效期
+	purgeInterval    = time.Minute      // 内存去重记录的清理间隔
+	dedupKeyPrefix   = "event:"
+	replayKeyPrefix  = "replay:"
+	dedupKeySplitter = "|"
+)
+
+var errReplayedRequest = errors.New("replayed callback request")
+
+type (
+	// SeenStore 去重记录存储，可替换为 Redis 等共享存储以便多实例间去重
+	SeenStore interface {
+		// SetIfAbsent 记录不存在时写入并返回 true，已存在且未过期时返回 false
+		SetIfAbsent(key string, ttl time.Duration) (bool, error)
+		// Delete 删除记录
+		Delete(key string) error
+	}
+
+	// DedupOptions 去重配置
+	DedupOptions struct {
+		Store               SeenStore                                     // （选填）去重记录存储，默认为内存存储
+		TTL                 time.Duration                                 // （选填）去重记录的有效期，默认为10分钟
+		KeyFunc             func(command string, data interface{}) string // （选填）自定义事件标识，返回空字符串时使用默认规则
+		DisableReplayReject bool                                          // （选填）是否关闭签名请求的重放拒绝
+	}
+
+	// deduplicator 去重器
+	deduplicator struct {
+		opt DedupOptions
+	}
+
+	// memorySeenStore 内存去重记录存储
+	memorySeenStore struct {
+		mu        sync.Mutex
+		items     map[string]int64
+		lastPurge int64
+		now       func() time.Time
+	}
+)
+
+// NewMemorySeenStore 创建内存去重记录存储
+func NewMemorySeenStore() SeenStore {
+	return &memorySeenStore{items: make(map[string]int64), now: time.Now}
+}
+
+// EnableDedup 开启去重与重放防护
+// After 类回调按事件标识去重，重复投递的回调直接应答成功而不再执行处理器，同步处理失败时删除去重记录以便重试；
+// 设置了 token 时，签名、请求时间与请求内容完全相同的重放请求将被拒绝，同步处理失败时同样删除重放记录，相同的重试请求可再次处理
+func (c *callback) EnableDedup(opt ...*DedupOptions) {
+	o := DedupOptions{}
+	if len(opt) > 0 && opt[0] != nil {
+		o = *opt[0]
+	}
+
+	if o.Store == nil {
+		o.Store = NewMemorySeenStore()
+	}
+
+	if o.TTL <= 0 {
+		o.TTL = defaultDedupTTL
+	}
+
+	c.mu.Lock()
+	c.dedup = &deduplicator{opt: o}
+	c.mu.Unlock()
+}
+
+// deduplicate 检查回调是否为重放请求或重复投递的事件，返回需要在处理失败时释放的重放记录与去重记录
+func (c *callback) deduplicate(r *http.Request, command string, body []byte, event Event, data interface{}) (keys []string, duplicated bool, err error) {
+	c.mu.RLock()
+	d := c.dedup
+	c.mu.RUnlock()
+
+	if d == nil {
+		return
+	}
+
+	if c.token != "" && !d.opt.DisableReplayReject {
+		sign, _ := c.GetQuery(r, querySign)
+		requestTime, _ := c.GetQuery(r, querySignRequestTime)
+
+		// 签名仅由 token 与请求时间生成，同一秒内的不同回调签名相同，需结合请求内容识别重放
+		key := replayKeyPrefix + sign + dedupKeySplitter + requestTime + dedupKeySplitter + hashBody(command, body)
+		ok, e := d.opt.Store.SetIfAbsent(key, replayTTL)
+		if e != nil {
+			return nil, false, e
+		}
+		if !ok {
+			return nil, false, errReplayedRequest
+		}
+		keys = append(keys, key)
+	}
+
+	if !isAsyncEvent(event) {
+		return
+	}
+
+	id := ""
+	if d.opt.KeyFunc != nil {
+		id = d.opt.KeyFunc(command, data)
+	}
+	if id == "" {
+		id = EventKey(command, body, data)
+	}
+
+	key := dedupKeyPrefix + command + dedupKeySplitter + id
+
+	ok, err := d.opt.Store.SetIfAbsent(key, d.opt.TTL)
+	if err != nil {
+		c.release(keys...)
+		return nil, false, err
+	}
+
+	if !ok {
+		// 重复投递的事件直接应答成功，保留重放记录
+		return nil, true, nil
+	}
+
+	keys = append(keys, key)
+
+	return
+}
+
+// release 删除重放记录与去重记录
+func (c *callback) release(keys ...string) {
+	c.mu.RLock()
+	d := c.dedup
+	c.mu.RUnlock()
+
+	if d == nil {
+		return
+	}
+
+	for _, key := range keys {
+		_ = d.opt.Store.Delete(key)
+	}
+}
+
+// EventKey 获取回调事件的标识，重复投递的同一事件标识相同
+// 消息类回调优先使用 MsgKey 或 GroupId 与 MsgSeq，其余回调使用事件时间，无法确定标识时使用请求内容的摘要
+func EventKey(command string, body []byte, data interface{}) string {
+	switch d := data.(type) {
+	case *AfterPrivateMessageSend:
+		if d.MsgKey != "" {
+			return d.MsgKey
+		}
+		return joinKey(d.FromUserId, d.ToUserId, strconv.FormatUint(uint64(d.MsgSeq), 10), strconv.FormatUint(uint64(d.MsgRandom), 10), strconv.FormatInt(d.MsgTime, 10))
+	case *AfterPrivateMessageRevoke:
+		if d.MsgKey != "" {
+			return d.MsgKey
+		}
+	case *AfterPrivateMessageModify:
+		if d.MsgKey != "" {
+			return joinKey(d.MsgKey, strconv.FormatInt(d.EventTime, 10))
+		}
+	case *AfterPrivateMessageExtensionChange:
+		return joinKey(d.MsgKey, strconv.Itoa(d.OperateType), strconv.FormatInt(d.EventTime, 10))
+	case *AfterPrivateMessageReport:
+		return joinKey(d.ReportUserId, d.PeerUserId, strconv.FormatInt(d.LastReadTime, 10))
+	case *AfterGroupMessageSend:
+		return joinKey(d.GroupId, strconv.Itoa(d.MsgSeq))
+	case *AfterGroupMessageModify:
+		return joinKey(d.GroupId, strconv.Itoa(d.MsgSeq), strconv.FormatInt(d.EventTime, 10))
+	case *AfterGroupMessageExtensionChange:
+		return joinKey(d.GroupId, strconv.Itoa(d.MsgSeq), strconv.FormatInt(d.EventTime, 10))
+	case *StateChange:
+		return joinKey(d.Info.UserId, d.Info.Action, strconv.FormatInt(d.EventTime, 10))
+	case *AfterProfileUpdate:
+		return joinKey(d.UserId, strconv.FormatInt(d.EventTime, 10))
+	}
+
+	return hashBody(command, body)
+}
+
+// joinKey 拼接事件标识
+func joinKey(parts ...string) string {
+	return strings.Join(parts, dedupKeySplitter)
+}
+
+// hashBody 计算回调内容的摘要
+func hashBody(command string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(command))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// SetIfAbsent 记录不存在或已过期时写入并返回 true
+func (s *memorySeenStore) SetIfAbsent(key string, ttl time.Duration) (bool, error) {
+	now := s.now().UnixNano()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now-s.lastPurge >= int64(purgeInterval) {
+		for k, expireAt := range s.items {
+			if expireAt <= now {
+				delete(s.items, k)
+			}
+		}
+		s.lastPurge = now
+	}
+
+	if expireAt, ok := s.items[key]; ok && expireAt > now {
+		return false, nil
+	}
+
+	s.items[key] = now + int64(ttl)
+
+	return true, nil
+}
+
+// Delete 删除记录
+func (s *memorySeenStore) Delete(key string) error {
+	s.mu.Lock()
+	delete(s.items, key)
+	s.mu.Unlock()
+
+	return nil
+}
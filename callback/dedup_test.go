@@ -0,0 +1,176 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/19 12:30
+ * @Desc: 回调去重与重放防护测试
+ */
+
+package callback
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// fakeClock 可手动推进的时钟
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newFakeSeenStore() (*memorySeenStore, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	store := NewMemorySeenStore().(*memorySeenStore)
+	store.now = clock.Now
+
+	return store, clock
+}
+
+func TestMemorySeenStore_TTL(t *testing.T) {
+	store, clock := newFakeSeenStore()
+
+	if ok, _ := store.SetIfAbsent("k", time.Minute); !ok {
+		t.Fatal("first write should succeed")
+	}
+
+	clock.Advance(59 * time.Second)
+	if ok, _ := store.SetIfAbsent("k", time.Minute); ok {
+		t.Fatal("record should not expire before ttl")
+	}
+
+	clock.Advance(time.Second)
+	if ok, _ := store.SetIfAbsent("k", time.Minute); !ok {
+		t.Fatal("record should expire after ttl")
+	}
+
+	// 过期记录在清理间隔到达后被清除
+	clock.Advance(purgeInterval)
+	_, _ = store.SetIfAbsent("other", time.Minute)
+	if _, ok := store.items["k"]; ok {
+		t.Fatal("expired record should be purged")
+	}
+}
+
+func TestDeduplicate_ReplayReject(t *testing.T) {
+	store, clock := newFakeSeenStore()
+
+	c := NewCallback(1400000000, "token").(*callback)
+	c.EnableDedup(&DedupOptions{Store: store})
+
+	body := []byte(`{"CallbackCommand":"Sns.CallbackPrevFriendAdd"}`)
+	r := httptest.NewRequest("POST", "/callback?Sign=abc&RequestTime=1700000000", nil)
+
+	if _, _, err := c.deduplicate(r, commandBeforeFriendAdd, body, EventBeforeFriendAdd, &BeforeFriendAdd{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 签名、请求时间与请求内容相同的请求视为重放
+	if _, _, err := c.deduplicate(r, commandBeforeFriendAdd, body, EventBeforeFriendAdd, &BeforeFriendAdd{}); err != errReplayedRequest {
+		t.Fatalf("expected replayed request, got %v", err)
+	}
+
+	// 同一秒内签名相同但内容不同的回调不视为重放
+	other := []byte(`{"CallbackCommand":"Sns.CallbackPrevFriendAdd","RequesterAccount":"u1"}`)
+	if _, _, err := c.deduplicate(r, commandBeforeFriendAdd, other, EventBeforeFriendAdd, &BeforeFriendAdd{}); err != nil {
+		t.Fatal(err)
+	}
+
+	clock.Advance(replayTTL)
+	if _, _, err := c.deduplicate(r, commandBeforeFriendAdd, body, EventBeforeFriendAdd, &BeforeFriendAdd{}); err != nil {
+		t.Fatalf("replay record should expire after %s, got %v", replayTTL, err)
+	}
+}
+
+func TestDeduplicate_Event(t *testing.T) {
+	store, clock := newFakeSeenStore()
+
+	c := newTestCallback()
+	c.EnableDedup(&DedupOptions{Store: store, TTL: time.Minute})
+
+	r := httptest.NewRequest("POST", "/callback", nil)
+	data := &AfterGroupMessageSend{GroupId: "g1", MsgSeq: 1}
+
+	keys, duplicated, err := c.deduplicate(r, commandAfterGroupMessageSend, nil, EventAfterGroupMessageSend, data)
+	if err != nil || duplicated || len(keys) != 1 {
+		t.Fatalf("unexpected result: %q %v %v", keys, duplicated, err)
+	}
+
+	if _, duplicated, _ = c.deduplicate(r, commandAfterGroupMessageSend, nil, EventAfterGroupMessageSend, data); !duplicated {
+		t.Fatal("redelivered event should be duplicated")
+	}
+
+	// 处理失败释放去重记录后允许重试
+	c.release(keys...)
+	if _, duplicated, _ = c.deduplicate(r, commandAfterGroupMessageSend, nil, EventAfterGroupMessageSend, data); duplicated {
+		t.Fatal("released event should not be duplicated")
+	}
+
+	clock.Advance(time.Minute)
+	if _, duplicated, _ = c.deduplicate(r, commandAfterGroupMessageSend, nil, EventAfterGroupMessageSend, data); duplicated {
+		t.Fatal("event should not be duplicated after ttl")
+	}
+}
+
+func TestEventKey(t *testing.T) {
+	if key := EventKey(commandAfterPrivateMessageSend, nil, &AfterPrivateMessageSend{MsgKey: "k1", FromUserId: "u1"}); key != "k1" {
+		t.Fatalf("expected msg key, got %s", key)
+	}
+
+	if key := EventKey(commandAfterGroupMessageSend, nil, &AfterGroupMessageSend{GroupId: "g1", MsgSeq: 2}); key != "g1|2" {
+		t.Fatalf("expected group and seq, got %s", key)
+	}
+
+	// 无法确定标识的回调使用请求内容的摘要
+	a := EventKey(commandAfterFriendAdd, []byte(`{"a":1}`), &AfterFriendAdd{})
+	b := EventKey(commandAfterFriendAdd, []byte(`{"a":2}`), &AfterFriendAdd{})
+	if a == b || a != EventKey(commandAfterFriendAdd, []byte(`{"a":1}`), &AfterFriendAdd{}) {
+		t.Fatalf("unexpected body digests: %s %s", a, b)
+	}
+}
+
+func TestDeduplicate_RetryAfterFailure(t *testing.T) {
+	c := NewCallback(1400000000, "token").(*callback)
+	c.EnableDedup()
+
+	calls := 0
+	c.OnAfterFriendAdd(func(ctx context.Context, ack Ack, data *AfterFriendAdd) {
+		calls++
+		if calls == 1 {
+			_ = ack.AckFailure("failed")
+		}
+	})
+
+	requestTime := strconv.FormatInt(time.Now().Unix(), 10)
+	sum := sha256.Sum256([]byte("token" + requestTime))
+	query := "SdkAppid=1400000000&CallbackCommand=" + commandAfterFriendAdd + "&Sign=" + hex.EncodeToString(sum[:]) + "&RequestTime=" + requestTime
+	body := `{"CallbackCommand":"Sns.CallbackFriendAdd","PairList":[{"From_Account":"u1","To_Account":"u2"}]}`
+
+	// 处理失败后释放重放记录与去重记录，相同的重试请求再次处理
+	for i, status := range []string{ackFailureStatus, ackSuccessStatus} {
+		if code, resp := doCallback(c.ServeHTTP, query, body); code != http.StatusOK || resp.ActionStatus != status {
+			t.Fatalf("unexpected response of request %d: %d %+v", i+1, code, resp)
+		}
+	}
+
+	if calls != 2 {
+		t.Fatalf("expected handler called twice, got %d", calls)
+	}
+
+	// 处理成功后的相同请求视为重放
+	if code, _ := doCallback(c.ServeHTTP, query, body); code != http.StatusConflict {
+		t.Fatalf("expected replayed request rejected, got %d", code)
+	}
+}
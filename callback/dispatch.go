@@ -0,0 +1,233 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 19:30
+ * @Desc: 回调事件分发与应答合并
+ */
+
+package callback
+
+import (
+	"context"
+	"errors"
+)
+
+var errAlreadyAcked = errors.New("callback already acked")
+
+// recordAck 记录单个处理器的应答，由分发器合并所有处理器的应答后统一应答
+type recordAck struct {
+	resp  interface{}
+	acked bool
+}
+
+// dispatch 分发回调事件，中间件分别包裹该事件注册的每个处理器后依次执行
+func (c *callback) dispatch(ctx context.Context, ack Ack, command string, event Event, data interface{}) {
+	c.mu.RLock()
+	handlers := make([]EventHandlerFunc, len(c.handlers[event]))
+	for i, handler := range c.handlers[event] {
+		for j := len(c.middlewares) - 1; j >= 0; j-- {
+			handler = c.middlewares[j](handler)
+		}
+		handlers[i] = handler
+	}
+	c.mu.RUnlock()
+
+	compose(handlers, isBeforeEvent(event))(withEvent(ctx, event, command), ack, data)
+}
+
+// compose 将多个处理器组合为一个处理器
+// 处理器按注册顺序执行，Before 类回调（veto 为 true）任一处理器应答失败（ErrorCode 非0）即视为否决，立即应答且不再执行后续处理器；
+// After 类回调不存在否决，处理器应答失败时仍执行后续处理器，全部执行完成后应答第一个失败的应答；
+// 其余应答按事件合并：加好友类回调按 UserID 合并处理结果，拉人入群回调合并拒绝列表，
+// 发消息之前回调将修改后的消息体回写到回调数据中，后续处理器可基于修改后的消息继续处理；
+// 没有处理器应答时默认应答成功
+func compose(handlers []EventHandlerFunc, veto bool) EventHandlerFunc {
+	return func(ctx context.Context, ack Ack, data interface{}) {
+		var merged, failed interface{}
+
+		for _, handler := range handlers {
+			rec := &recordAck{}
+			handler(ctx, rec, data)
+			if !rec.acked {
+				continue
+			}
+
+			if isVetoed(rec.resp) {
+				if veto {
+					_ = ack.Ack(rec.resp)
+					return
+				}
+
+				if failed == nil {
+					failed = rec.resp
+				}
+				continue
+			}
+
+			merged = mergeResp(merged, rec.resp, data)
+		}
+
+		if failed != nil {
+			_ = ack.Ack(failed)
+		} else if merged == nil {
+			_ = ack.AckSuccess(ackSuccessCode)
+		} else {
+			_ = ack.Ack(merged)
+		}
+	}
+}
+
+// Ack 记录应答，同一处理器多次应答时以最后一次为准
+func (a *recordAck) Ack(resp interface{}) error {
+	a.resp = resp
+	a.acked = true
+	return nil
+}
+
+// AckFailure 应答失败
+func (a *recordAck) AckFailure(message ...string) error {
+	return a.Ack(failureResp(message...))
+}
+
+// AckSuccess 应答成功
+func (a *recordAck) AckSuccess(code int, message ...string) error {
+	return a.Ack(successResp(code, message...))
+}
+
+// baseRespOf 获取应答中的基础应答信息
+func baseRespOf(resp interface{}) *BaseResp {
+	switch r := resp.(type) {
+	case BaseResp:
+		return &r
+	case *BaseResp:
+		return r
+	case *BeforeFriendAddResp:
+		return &r.BaseResp
+	case *BeforeFriendResponseResp:
+		return &r.BaseResp
+	case *BeforePrivateMessageSendResp:
+		return &r.BaseResp
+	case *BeforeInviteJoinGroupResp:
+		return &r.BaseResp
+	case *BeforeGroupMessageSendResp:
+		return &r.BaseResp
+	}
+
+	return nil
+}
+
+// isVetoed 判断应答是否否决了本次操作
+func isVetoed(resp interface{}) bool {
+	base := baseRespOf(resp)
+	return base != nil && (base.ErrorCode != ackSuccessCode || base.ActionStatus == ackFailureStatus)
+}
+
+// mergeResp 合并两个处理器的应答
+func mergeResp(prev, next interface{}, data interface{}) interface{} {
+	switch r := next.(type) {
+	case BaseResp, *BaseResp:
+		if prev != nil {
+			return prev
+		}
+	case *BeforeFriendAddResp:
+		if p, ok := prev.(*BeforeFriendAddResp); ok {
+			p.Results = mergeFriendAddResults(p.Results, r.Results)
+			return p
+		}
+	case *BeforeFriendResponseResp:
+		if p, ok := prev.(*BeforeFriendResponseResp); ok {
+			p.Results = mergeFriendResponseResults(p.Results, r.Results)
+			return p
+		}
+	case *BeforeInviteJoinGroupResp:
+		if p, ok := prev.(*BeforeInviteJoinGroupResp); ok {
+			p.RefusedMemberUserIds = mergeUserIds(p.RefusedMemberUserIds, r.RefusedMemberUserIds)
+			return p
+		}
+	case *BeforePrivateMessageSendResp:
+		if d, ok := data.(*BeforePrivateMessageSend); ok {
+			if len(r.MsgBody) > 0 {
+				d.MsgBody = r.MsgBody
+			}
+			if r.CloudCustomData != "" {
+				d.CloudCustomData = r.CloudCustomData
+			}
+		}
+		if p, ok := prev.(*BeforePrivateMessageSendResp); ok {
+			if len(r.MsgBody) > 0 {
+				p.MsgBody = r.MsgBody
+			}
+			if r.CloudCustomData != "" {
+				p.CloudCustomData = r.CloudCustomData
+			}
+			return p
+		}
+	case *BeforeGroupMessageSendResp:
+		if d, ok := data.(*BeforeGroupMessageSend); ok && len(r.MsgBody) > 0 {
+			d.MsgBody = r.MsgBody
+		}
+		if p, ok := prev.(*BeforeGroupMessageSendResp); ok {
+			if len(r.MsgBody) > 0 {
+				p.MsgBody = r.MsgBody
+			}
+			return p
+		}
+	}
+
+	return next
+}
+
+// mergeFriendAddResults 按 UserID 合并加好友处理结果，拒绝优先
+func mergeFriendAddResults(prev, next []*BeforeFriendAddResult) []*BeforeFriendAddResult {
+	index := make(map[string]int, len(prev))
+	for i, result := range prev {
+		index[result.UserId] = i
+	}
+
+	for _, result := range next {
+		if i, ok := index[result.UserId]; !ok {
+			index[result.UserId] = len(prev)
+			prev = append(prev, result)
+		} else if prev[i].ResultCode == ackSuccessCode {
+			prev[i] = result
+		}
+	}
+
+	return prev
+}
+
+// mergeFriendResponseResults 按 UserID 合并加好友回应处理结果，拒绝优先
+func mergeFriendResponseResults(prev, next []*BeforeFriendResponseResult) []*BeforeFriendResponseResult {
+	index := make(map[string]int, len(prev))
+	for i, result := range prev {
+		index[result.UserId] = i
+	}
+
+	for _, result := range next {
+		if i, ok := index[result.UserId]; !ok {
+			index[result.UserId] = len(prev)
+			prev = append(prev, result)
+		} else if prev[i].ResultCode == ackSuccessCode {
+			prev[i] = result
+		}
+	}
+
+	return prev
+}
+
+// mergeUserIds 合并 UserID 列表并去重
+func mergeUserIds(prev, next []string) []string {
+	exists := make(map[string]bool, len(prev))
+	for _, userId := range prev {
+		exists[userId] = true
+	}
+
+	for _, userId := range next {
+		if !exists[userId] {
+			exists[userId] = true
+			prev = append(prev, userId)
+		}
+	}
+
+	return prev
+}
@@ -0,0 +1,73 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/19 11:30
+ * @Desc: 回调事件分发测试
+ */
+
+package callback
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"testing"
+)
+
+func newTestCallback() *callback {
+	return NewCallback(1400000000).(*callback)
+}
+
+func TestDispatch_RecoveryPerHandler(t *testing.T) {
+	c := newTestCallback()
+	c.Use(Recovery(log.New(ioutil.Discard, "", 0)))
+
+	var called []string
+	for _, event := range []Event{EventAfterFriendAdd, EventBeforeFriendAdd} {
+		c.Register(event, func(ctx context.Context, ack Ack, data interface{}) {
+			called = append(called, "panic")
+			panic("boom")
+		})
+		c.Register(event, func(ctx context.Context, ack Ack, data interface{}) {
+			called = append(called, "next")
+			_ = ack.AckSuccess(0)
+		})
+	}
+
+	// After 类回调：panic 的处理器应答失败，后续处理器仍执行
+	rec := &recordAck{}
+	c.dispatch(context.Background(), rec, commandAfterFriendAdd, EventAfterFriendAdd, &AfterFriendAdd{})
+	if len(called) != 2 || called[1] != "next" {
+		t.Fatalf("unexpected handlers called: %v", called)
+	}
+
+	if !isVetoed(rec.resp) || baseRespOf(rec.resp).ErrorInfo != "boom" {
+		t.Fatalf("expected failure response, got %+v", rec.resp)
+	}
+
+	// Before 类回调：panic 的处理器否决本次操作，不再执行后续处理器
+	called = nil
+	rec = &recordAck{}
+	c.dispatch(context.Background(), rec, commandBeforeFriendAdd, EventBeforeFriendAdd, &BeforeFriendAdd{})
+	if len(called) != 1 || !isVetoed(rec.resp) {
+		t.Fatalf("unexpected handlers called: %v, resp: %+v", called, rec.resp)
+	}
+}
+
+func TestDispatch_MiddlewareWithoutHandlers(t *testing.T) {
+	c := newTestCallback()
+
+	calls := 0
+	c.Use(func(next EventHandlerFunc) EventHandlerFunc {
+		return func(ctx context.Context, ack Ack, data interface{}) {
+			calls++
+			next(ctx, ack, data)
+		}
+	})
+
+	rec := &recordAck{}
+	c.dispatch(context.Background(), rec, commandAfterFriendAdd, EventAfterFriendAdd, &AfterFriendAdd{})
+	if calls != 0 || isVetoed(rec.resp) {
+		t.Fatalf("unexpected calls: %d, resp: %+v", calls, rec.resp)
+	}
+}
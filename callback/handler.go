@@ -0,0 +1,103 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 21:30
+ * @Desc: 标准 http.Handler 与路由适配
+ */
+
+package callback
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+const defaultMaxBodySize = 1 << 20 // 默认的请求体大小上限，单位为字节
+
+var errBodyTooLarge = errors.New("request body too large")
+
+type (
+	// HandlerOptions 回调 http.Handler 配置
+	HandlerOptions struct {
+		MaxBodySize int64                                 // （选填）请求体大小上限，单位为字节，默认为1MB，超出时应答 413
+		Timeout     time.Duration                         // （选填）处理超时时间，设置后处理器收到的上下文将在超时后取消
+		ContextFunc func(r *http.Request) context.Context // （选填）自定义处理器收到的上下文，默认为请求的上下文
+	}
+
+	// Mux 标准库风格的路由，http.ServeMux、chi.Router 等均实现了该接口
+	Mux interface {
+		Handle(pattern string, handler http.Handler)
+	}
+
+	// handler 回调 http.Handler
+	handler struct {
+		c   *callback
+		opt HandlerOptions
+	}
+)
+
+// Handler 获取回调的 http.Handler
+// 可直接挂载到标准库及 chi 等兼容 http.Handler 的路由，gin、echo、fiber 可分别通过
+// gin.WrapH、echo.WrapHandler、adaptor.HTTPHandler 挂载
+func (c *callback) Handler(opt ...*HandlerOptions) http.Handler {
+	h := &handler{c: c}
+	if len(opt) > 0 && opt[0] != nil {
+		h.opt = *opt[0]
+	}
+
+	if h.opt.MaxBodySize <= 0 {
+		h.opt.MaxBodySize = defaultMaxBodySize
+	}
+
+	return h
+}
+
+// ServeHTTP 以默认配置处理回调请求
+func (c *callback) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.serve(r.Context(), &ack{w: w}, r, defaultMaxBodySize)
+}
+
+// ServeHTTP 处理回调请求，处理器收到的上下文继承自请求的上下文
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if h.opt.ContextFunc != nil {
+		ctx = h.opt.ContextFunc(r)
+	}
+
+	if h.opt.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.opt.Timeout)
+		defer cancel()
+	}
+
+	h.c.serve(ctx, &ack{w: w}, r, h.opt.MaxBodySize)
+}
+
+// Mount 将回调挂载到路由的指定路径
+func Mount(mux Mux, pattern string, cb Callback, opt ...*HandlerOptions) {
+	mux.Handle(pattern, cb.Handler(opt...))
+}
+
+// readBody 读取请求体，超出大小上限时返回 errBodyTooLarge，maxBodySize 小于等于0时不限制大小
+func readBody(r *http.Request, maxBodySize int64) ([]byte, error) {
+	defer r.Body.Close()
+
+	if maxBodySize <= 0 {
+		return ioutil.ReadAll(r.Body)
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(body)) > maxBodySize {
+		return nil, errBodyTooLarge
+	}
+
+	return body, nil
+}
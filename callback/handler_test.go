@@ -0,0 +1,67 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/19 13:30
+ * @Desc: 回调 http.Handler 测试
+ */
+
+package callback
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func doCallback(serve func(w http.ResponseWriter, r *http.Request), query, body string) (int, BaseResp) {
+	w := httptest.NewRecorder()
+	serve(w, httptest.NewRequest(http.MethodPost, "/callback?"+query, strings.NewReader(body)))
+
+	resp := BaseResp{}
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+
+	return w.Code, resp
+}
+
+func TestListen_LegacyResponse(t *testing.T) {
+	c := newTestCallback()
+	listen := func(w http.ResponseWriter, r *http.Request) {
+		c.Listen(context.Background(), w, r)
+	}
+
+	// 请求校验失败时仍以 200 状态码应答失败
+	code, resp := doCallback(listen, "SdkAppid=1&CallbackCommand=Sns.CallbackFriendAdd", "{}")
+	if code != http.StatusOK || resp.ActionStatus != ackFailureStatus {
+		t.Fatalf("unexpected response: %d %+v", code, resp)
+	}
+
+	// 不限制请求体大小
+	body := `{"CallbackCommand":"Sns.CallbackFriendAdd","Padding":"` + strings.Repeat("x", defaultMaxBodySize) + `"}`
+	code, resp = doCallback(listen, "SdkAppid=1400000000&CallbackCommand=Sns.CallbackFriendAdd", body)
+	if code != http.StatusOK || resp.ActionStatus != ackSuccessStatus {
+		t.Fatalf("unexpected response: %d %+v", code, resp)
+	}
+}
+
+func TestHandler_StatusCodes(t *testing.T) {
+	c := newTestCallback()
+	h := c.Handler(&HandlerOptions{MaxBodySize: 64})
+
+	code, _ := doCallback(h.ServeHTTP, "SdkAppid=1&CallbackCommand=Sns.CallbackFriendAdd", "{}")
+	if code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", code)
+	}
+
+	code, _ = doCallback(h.ServeHTTP, "SdkAppid=1400000000&CallbackCommand=Sns.CallbackFriendAdd", strings.Repeat(" ", 65)+"{}")
+	if code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413, got %d", code)
+	}
+
+	code, resp := doCallback(h.ServeHTTP, "SdkAppid=1400000000&CallbackCommand=Sns.CallbackFriendAdd", "{}")
+	if code != http.StatusOK || resp.ActionStatus != ackSuccessStatus {
+		t.Fatalf("unexpected response: %d %+v", code, resp)
+	}
+}
@@ -0,0 +1,136 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 19:30
+ * @Desc: 回调中间件
+ */
+
+package callback
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"time"
+)
+
+type (
+	// Middleware 回调中间件，包裹事件处理器，可用于日志、异常恢复、监控、鉴权等
+	Middleware func(next EventHandlerFunc) EventHandlerFunc
+
+	contextKey int
+
+	// eventInfo 当前处理的回调事件信息
+	eventInfo struct {
+		event   Event
+		command string
+	}
+
+	// statusAck 记录应答内容的应答器，供中间件获取处理结果
+	statusAck struct {
+		ack  Ack
+		resp interface{}
+	}
+)
+
+const (
+	eventInfoKey contextKey = iota
+	replayKey
+)
+
+// withEvent 在上下文中记录当前处理的回调事件
+func withEvent(ctx context.Context, event Event, command string) context.Context {
+	return context.WithValue(ctx, eventInfoKey, &eventInfo{event: event, command: command})
+}
+
+// EventFromContext 获取上下文中当前处理的回调事件
+func EventFromContext(ctx context.Context) Event {
+	if info, ok := ctx.Value(eventInfoKey).(*eventInfo); ok {
+		return info.event
+	}
+
+	return 0
+}
+
+// CommandFromContext 获取上下文中当前处理的回调命令
+func CommandFromContext(ctx context.Context) string {
+	if info, ok := ctx.Value(eventInfoKey).(*eventInfo); ok {
+		return info.command
+	}
+
+	return ""
+}
+
+// withReplay 在上下文中标记当前处理的回调为重放的回调
+func withReplay(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replayKey, true)
+}
+
+// IsReplay 判断上下文中当前处理的回调是否为重放的归档回调
+func IsReplay(ctx context.Context) bool {
+	replay, _ := ctx.Value(replayKey).(bool)
+	return replay
+}
+
+// Recovery 异常恢复中间件，处理器发生 panic 时应答失败，避免回调服务崩溃
+func Recovery(logger ...*log.Logger) Middleware {
+	l := defaultLogger(logger...)
+
+	return func(next EventHandlerFunc) EventHandlerFunc {
+		return func(ctx context.Context, ack Ack, data interface{}) {
+			defer func() {
+				if err := recover(); err != nil {
+					l.Printf("callback %s panic: %v\n%s", CommandFromContext(ctx), err, debug.Stack())
+					_ = ack.AckFailure(fmt.Sprintf("%v", err))
+				}
+			}()
+
+			next(ctx, ack, data)
+		}
+	}
+}
+
+// Logging 日志中间件，记录每个回调的命令、耗时与应答结果
+func Logging(logger ...*log.Logger) Middleware {
+	l := defaultLogger(logger...)
+
+	return func(next EventHandlerFunc) EventHandlerFunc {
+		return func(ctx context.Context, ack Ack, data interface{}) {
+			start := time.Now()
+			sa := &statusAck{ack: ack}
+
+			next(ctx, sa, data)
+
+			if base := baseRespOf(sa.resp); base != nil {
+				l.Printf("callback %s handled in %s, status: %s, code: %d", CommandFromContext(ctx), time.Since(start), base.ActionStatus, base.ErrorCode)
+			} else {
+				l.Printf("callback %s handled in %s", CommandFromContext(ctx), time.Since(start))
+			}
+		}
+	}
+}
+
+// Ack 应答并记录应答内容
+func (a *statusAck) Ack(resp interface{}) error {
+	a.resp = resp
+	return a.ack.Ack(resp)
+}
+
+// AckFailure 应答失败
+func (a *statusAck) AckFailure(message ...string) error {
+	return a.Ack(failureResp(message...))
+}
+
+// AckSuccess 应答成功
+func (a *statusAck) AckSuccess(code int, message ...string) error {
+	return a.Ack(successResp(code, message...))
+}
+
+func defaultLogger(logger ...*log.Logger) *log.Logger {
+	if len(logger) > 0 && logger[0] != nil {
+		return logger[0]
+	}
+
+	return log.Default()
+}
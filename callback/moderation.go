@@ -0,0 +1,236 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 22:00
+ * @Desc: 发消息之前回调的内容审核
+ */
+
+package callback
+
+import (
+	"context"
+	"time"
+
+	"github.com/default-yarns/tencent-im/internal/types"
+)
+
+const (
+	ModerationRejectCode  = 1 // 拒绝发言，客户端将收到发送失败
+	ModerationDiscardCode = 2 // 静默丢弃，客户端认为发送成功但对方不会收到
+)
+
+// ModerationAction 审核动作
+type ModerationAction int
+
+const (
+	ModerationPass    ModerationAction = iota // 放行
+	ModerationReject                          // 拒绝
+	ModerationRewrite                         // 改写消息体后放行
+)
+
+// String 获取审核动作名称
+func (a ModerationAction) String() string {
+	switch a {
+	case ModerationReject:
+		return "reject"
+	case ModerationRewrite:
+		return "rewrite"
+	default:
+		return "pass"
+	}
+}
+
+type (
+	// ModerationMessage 待审核的消息
+	ModerationMessage struct {
+		Event           Event            // 回调事件，为 EventBeforePrivateMessageSend 或 EventBeforeGroupMessageSend
+		FromUserId      string           // 消息发送者 UserID
+		ToUserId        string           // 单聊消息接收者 UserID
+		GroupId         string           // 群消息所属的群ID
+		MsgBody         []*types.MsgBody // 消息体，前序审核环节改写后为改写后的消息体
+		CloudCustomData string           // 单聊消息自定义数据
+	}
+
+	// ModerationDecision 审核结果
+	ModerationDecision struct {
+		Action  ModerationAction // 审核动作
+		Code    int              // 拒绝时的错误码，默认为 ModerationRejectCode；单聊可使用[120001, 130000]、群聊可使用[10100, 10200]的自定义错误码透传给客户端
+		Reason  string           // 拒绝或改写的原因
+		MsgBody []*types.MsgBody // 改写后的消息体
+	}
+
+	// ModerationStage 审核环节
+	ModerationStage interface {
+		// Name 审核环节名称
+		Name() string
+		// Moderate 审核消息，返回 nil 表示放行
+		Moderate(ctx context.Context, msg *ModerationMessage) (*ModerationDecision, error)
+	}
+
+	// ModerationAudit 审核记录，每条消息审核完成后生成一条
+	ModerationAudit struct {
+		Time          time.Time        // 审核时间
+		Event         Event            // 回调事件
+		FromUserId    string           // 消息发送者 UserID
+		ToUserId      string           // 单聊消息接收者 UserID
+		GroupId       string           // 群消息所属的群ID
+		Action        ModerationAction // 最终的审核动作
+		Stage         string           // 拒绝消息的审核环节
+		RewriteStages []string         // 改写消息的审核环节
+		Code          int              // 拒绝时的错误码
+		Reason        string           // 拒绝或改写的原因
+		Error         string           // 审核环节执行出错的原因
+		Original      []*types.MsgBody // 原始消息体
+		MsgBody       []*types.MsgBody // 改写后的消息体
+		Elapsed       time.Duration    // 审核耗时
+	}
+
+	// ModeratorOptions 审核器配置
+	ModeratorOptions struct {
+		Stages     []ModerationStage            // （必填）审核环节，按顺序执行，任一环节拒绝后不再执行后续环节
+		FailClosed bool                         // （选填）审核环节出错时是否拒绝消息，默认放行
+		ErrorCode  int                          // （选填）审核环节出错且拒绝消息时的错误码，默认为 ModerationRejectCode
+		OnAudit    func(audit *ModerationAudit) // （选填）审核记录通知
+	}
+
+	// Moderator 内容审核器
+	Moderator struct {
+		opt ModeratorOptions
+	}
+
+	// stageFunc 函数形式的审核环节
+	stageFunc struct {
+		name string
+		fn   func(ctx context.Context, msg *ModerationMessage) (*ModerationDecision, error)
+	}
+)
+
+// NewModerator 创建内容审核器
+func NewModerator(opt *ModeratorOptions) *Moderator {
+	m := &Moderator{}
+	if opt != nil {
+		m.opt = *opt
+	}
+
+	if m.opt.ErrorCode == 0 {
+		m.opt.ErrorCode = ModerationRejectCode
+	}
+
+	return m
+}
+
+// NewModerationStage 以函数创建审核环节
+func NewModerationStage(name string, fn func(ctx context.Context, msg *ModerationMessage) (*ModerationDecision, error)) ModerationStage {
+	return &stageFunc{name: name, fn: fn}
+}
+
+// Name 审核环节名称
+func (s *stageFunc) Name() string {
+	return s.name
+}
+
+// Moderate 审核消息
+func (s *stageFunc) Moderate(ctx context.Context, msg *ModerationMessage) (*ModerationDecision, error) {
+	return s.fn(ctx, msg)
+}
+
+// Listen 注册发单聊消息之前回调与群内发言之前回调，审核后应答对应的应答结构
+func (m *Moderator) Listen(cb Callback) {
+	cb.OnBeforePrivateMessageSend(func(ctx context.Context, ack *BeforePrivateMessageSendAck, data *BeforePrivateMessageSend) {
+		decision := m.Moderate(ctx, &ModerationMessage{
+			Event:           EventBeforePrivateMessageSend,
+			FromUserId:      data.FromUserId,
+			ToUserId:        data.ToUserId,
+			MsgBody:         data.MsgBody,
+			CloudCustomData: data.CloudCustomData,
+		})
+
+		resp := &BeforePrivateMessageSendResp{}
+		switch decision.Action {
+		case ModerationReject:
+			resp.BaseResp = rejectResp(decision.Code, decision.Reason)
+		case ModerationRewrite:
+			resp.MsgBody = decision.MsgBody
+		}
+
+		_ = ack.AckResp(resp)
+	})
+
+	cb.OnBeforeGroupMessageSend(func(ctx context.Context, ack *BeforeGroupMessageSendAck, data *BeforeGroupMessageSend) {
+		decision := m.Moderate(ctx, &ModerationMessage{
+			Event:      EventBeforeGroupMessageSend,
+			FromUserId: data.FromUserId,
+			GroupId:    data.GroupId,
+			MsgBody:    data.MsgBody,
+		})
+
+		resp := &BeforeGroupMessageSendResp{}
+		switch decision.Action {
+		case ModerationReject:
+			resp.BaseResp = rejectResp(decision.Code, decision.Reason)
+		case ModerationRewrite:
+			resp.MsgBody = decision.MsgBody
+		}
+
+		_ = ack.AckResp(resp)
+	})
+}
+
+// Moderate 按顺序执行审核环节并返回最终的审核结果
+// 环节改写消息后，后续环节审核改写后的消息；任一环节拒绝后立即返回拒绝
+func (m *Moderator) Moderate(ctx context.Context, msg *ModerationMessage) *ModerationDecision {
+	start := time.Now()
+	audit := &ModerationAudit{
+		Time:       start,
+		Event:      msg.Event,
+		FromUserId: msg.FromUserId,
+		ToUserId:   msg.ToUserId,
+		GroupId:    msg.GroupId,
+		Original:   msg.MsgBody,
+	}
+
+	final := &ModerationDecision{Action: ModerationPass}
+
+	for _, stage := range m.opt.Stages {
+		decision, err := stage.Moderate(ctx, msg)
+		if err != nil {
+			audit.Error = stage.Name() + ": " + err.Error()
+			if !m.opt.FailClosed {
+				continue
+			}
+			decision = &ModerationDecision{Action: ModerationReject, Code: m.opt.ErrorCode, Reason: err.Error()}
+		}
+
+		if decision == nil || decision.Action == ModerationPass {
+			continue
+		}
+
+		if decision.Action == ModerationRewrite {
+			msg.MsgBody = decision.MsgBody
+			final.Action = ModerationRewrite
+			final.MsgBody = decision.MsgBody
+			final.Reason = decision.Reason
+			audit.RewriteStages = append(audit.RewriteStages, stage.Name())
+			continue
+		}
+
+		final = &ModerationDecision{Action: ModerationReject, Code: decision.Code, Reason: decision.Reason}
+		if final.Code == 0 {
+			final.Code = ModerationRejectCode
+		}
+		audit.Stage = stage.Name()
+		break
+	}
+
+	audit.Action = final.Action
+	audit.Code = final.Code
+	audit.Reason = final.Reason
+	audit.MsgBody = final.MsgBody
+	audit.Elapsed = time.Since(start)
+
+	if m.opt.OnAudit != nil {
+		m.opt.OnAudit(audit)
+	}
+
+	return final
+}
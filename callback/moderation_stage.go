@@ -0,0 +1,358 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 22:00
+ * @Desc: 内置的内容审核环节
+ */
+
+package callback
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/default-yarns/tencent-im/internal/types"
+)
+
+var urlPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"'，。！？、]+`)
+
+type (
+	// TextFilterOptions 文本过滤配置
+	TextFilterOptions struct {
+		Mask        bool   // （选填）命中时是否将命中内容替换后放行，默认拒绝
+		Replacement string // （选填）替换命中内容的文本，默认为与命中内容等长的"*"
+		Code        int    // （选填）拒绝时的错误码，默认为 ModerationRejectCode
+		Reason      string // （选填）拒绝或改写的原因
+	}
+
+	// textStage 文本类审核环节，仅审核文本消息元素
+	textStage struct {
+		name    string
+		opt     TextFilterOptions
+		find    func(text string) []string
+		replace func(text string, mask func(hit string) string) string
+	}
+
+	// rateLimitStage 按发送者限制发言频率的审核环节
+	rateLimitStage struct {
+		limit    int
+		window   time.Duration
+		code     int
+		mu       sync.Mutex
+		counters map[string]*rateCounter
+		lastGC   time.Time
+		now      func() time.Time
+	}
+
+	rateCounter struct {
+		start time.Time
+		count int
+	}
+
+	// classifierStage 自定义分类器审核环节
+	classifierStage struct {
+		name      string
+		classify  func(ctx context.Context, text string) (float64, error)
+		threshold float64
+		code      int
+	}
+)
+
+// NewKeywordStage 创建关键词审核环节，消息文本包含任一关键词（不区分大小写）时拒绝或替换
+func NewKeywordStage(keywords []string, opt ...*TextFilterOptions) ModerationStage {
+	words := make([]string, 0, len(keywords))
+	for _, keyword := range keywords {
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			words = append(words, regexp.QuoteMeta(keyword))
+		}
+	}
+
+	var pattern *regexp.Regexp
+	if len(words) > 0 {
+		pattern = regexp.MustCompile("(?i)" + strings.Join(words, "|"))
+	}
+
+	return newRegexpStage("keyword", pattern, opt...)
+}
+
+// NewRegexpStage 创建正则表达式审核环节，消息文本匹配任一正则表达式时拒绝或替换
+func NewRegexpStage(patterns []string, opt ...*TextFilterOptions) (ModerationStage, error) {
+	items := make([]string, 0, len(patterns))
+	for _, p := range patterns {
+		if _, err := regexp.Compile(p); err != nil {
+			return nil, err
+		}
+		items = append(items, "(?:"+p+")")
+	}
+
+	var pattern *regexp.Regexp
+	if len(items) > 0 {
+		pattern = regexp.MustCompile(strings.Join(items, "|"))
+	}
+
+	return newRegexpStage("regexp", pattern, opt...), nil
+}
+
+// NewURLAllowlistStage 创建链接白名单审核环节，消息文本中包含不在白名单域名（含子域名）下的链接时拒绝或替换
+func NewURLAllowlistStage(domains []string, opt ...*TextFilterOptions) ModerationStage {
+	allowed := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		if domain = strings.ToLower(strings.TrimSpace(domain)); domain != "" {
+			allowed = append(allowed, strings.TrimPrefix(domain, "."))
+		}
+	}
+
+	isAllowed := func(link string) bool {
+		if !strings.Contains(link, "://") {
+			link = "http://" + link
+		}
+
+		u, err := url.Parse(link)
+		if err != nil {
+			return false
+		}
+
+		host := strings.ToLower(u.Hostname())
+		for _, domain := range allowed {
+			if host == domain || strings.HasSuffix(host, "."+domain) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	s := &textStage{name: "url_allowlist", opt: textFilterOptions(opt...)}
+	s.find = func(text string) (hits []string) {
+		for _, link := range urlPattern.FindAllString(text, -1) {
+			if !isAllowed(link) {
+				hits = append(hits, link)
+			}
+		}
+		return
+	}
+	s.replace = func(text string, mask func(hit string) string) string {
+		return urlPattern.ReplaceAllStringFunc(text, func(link string) string {
+			if isAllowed(link) {
+				return link
+			}
+			return mask(link)
+		})
+	}
+
+	return s
+}
+
+// NewRateLimitStage 创建发言频率审核环节，同一发送者在时间窗口内的发言数超过上限时拒绝
+func NewRateLimitStage(limit int, window time.Duration, code ...int) ModerationStage {
+	s := &rateLimitStage{
+		limit:    limit,
+		window:   window,
+		code:     ModerationRejectCode,
+		counters: make(map[string]*rateCounter),
+		now:      time.Now,
+	}
+
+	if len(code) > 0 && code[0] != 0 {
+		s.code = code[0]
+	}
+
+	return s
+}
+
+// NewClassifierStage 创建自定义分类器审核环节，分类器对消息文本打分，分数不低于阈值时拒绝
+func NewClassifierStage(name string, classify func(ctx context.Context, text string) (float64, error), threshold float64, code ...int) ModerationStage {
+	s := &classifierStage{
+		name:      name,
+		classify:  classify,
+		threshold: threshold,
+		code:      ModerationRejectCode,
+	}
+
+	if len(code) > 0 && code[0] != 0 {
+		s.code = code[0]
+	}
+
+	return s
+}
+
+func newRegexpStage(name string, pattern *regexp.Regexp, opt ...*TextFilterOptions) ModerationStage {
+	s := &textStage{name: name, opt: textFilterOptions(opt...)}
+	s.find = func(text string) []string {
+		if pattern == nil {
+			return nil
+		}
+		return pattern.FindAllString(text, -1)
+	}
+	s.replace = func(text string, mask func(hit string) string) string {
+		if pattern == nil {
+			return text
+		}
+		return pattern.ReplaceAllStringFunc(text, mask)
+	}
+
+	return s
+}
+
+func textFilterOptions(opt ...*TextFilterOptions) TextFilterOptions {
+	o := TextFilterOptions{}
+	if len(opt) > 0 && opt[0] != nil {
+		o = *opt[0]
+	}
+
+	if o.Code == 0 {
+		o.Code = ModerationRejectCode
+	}
+
+	return o
+}
+
+// Name 审核环节名称
+func (s *textStage) Name() string {
+	return s.name
+}
+
+// Moderate 审核消息中的文本消息元素
+func (s *textStage) Moderate(ctx context.Context, msg *ModerationMessage) (*ModerationDecision, error) {
+	var hits []string
+	for _, body := range msg.MsgBody {
+		if text, ok := msgText(body); ok {
+			hits = append(hits, s.find(text)...)
+		}
+	}
+
+	if len(hits) == 0 {
+		return nil, nil
+	}
+
+	reason := s.opt.Reason
+	if reason == "" {
+		reason = fmt.Sprintf("%s hit: %s", s.name, strings.Join(hits, ", "))
+	}
+
+	if !s.opt.Mask {
+		return &ModerationDecision{Action: ModerationReject, Code: s.opt.Code, Reason: reason}, nil
+	}
+
+	mask := func(hit string) string {
+		if s.opt.Replacement != "" {
+			return s.opt.Replacement
+		}
+		return strings.Repeat("*", len([]rune(hit)))
+	}
+
+	bodies := make([]*types.MsgBody, 0, len(msg.MsgBody))
+	for _, body := range msg.MsgBody {
+		if text, ok := msgText(body); ok {
+			body = &types.MsgBody{
+				MsgType:    body.MsgType,
+				MsgContent: types.MsgTextContent{Text: s.replace(text, mask)},
+			}
+		}
+		bodies = append(bodies, body)
+	}
+
+	return &ModerationDecision{Action: ModerationRewrite, Reason: reason, MsgBody: bodies}, nil
+}
+
+// Name 审核环节名称
+func (s *rateLimitStage) Name() string {
+	return "rate_limit"
+}
+
+// Moderate 统计发送者在当前时间窗口内的发言数
+func (s *rateLimitStage) Moderate(ctx context.Context, msg *ModerationMessage) (*ModerationDecision, error) {
+	if s.limit <= 0 || s.window <= 0 {
+		return nil, nil
+	}
+
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 定期清理已过期的计数
+	if now.Sub(s.lastGC) >= s.window {
+		for userId, counter := range s.counters {
+			if now.Sub(counter.start) >= s.window {
+				delete(s.counters, userId)
+			}
+		}
+		s.lastGC = now
+	}
+
+	counter, ok := s.counters[msg.FromUserId]
+	if !ok || now.Sub(counter.start) >= s.window {
+		counter = &rateCounter{start: now}
+		s.counters[msg.FromUserId] = counter
+	}
+
+	counter.count++
+	if counter.count > s.limit {
+		return &ModerationDecision{
+			Action: ModerationReject,
+			Code:   s.code,
+			Reason: fmt.Sprintf("rate limit exceeded: %d messages in %s", s.limit, s.window),
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// Name 审核环节名称
+func (s *classifierStage) Name() string {
+	return s.name
+}
+
+// Moderate 使用分类器对消息文本打分
+func (s *classifierStage) Moderate(ctx context.Context, msg *ModerationMessage) (*ModerationDecision, error) {
+	texts := make([]string, 0, len(msg.MsgBody))
+	for _, body := range msg.MsgBody {
+		if text, ok := msgText(body); ok {
+			texts = append(texts, text)
+		}
+	}
+
+	if len(texts) == 0 {
+		return nil, nil
+	}
+
+	score, err := s.classify(ctx, strings.Join(texts, "\n"))
+	if err != nil {
+		return nil, err
+	}
+
+	if score >= s.threshold {
+		return &ModerationDecision{
+			Action: ModerationReject,
+			Code:   s.code,
+			Reason: fmt.Sprintf("%s score %.2f exceeds threshold %.2f", s.name, score, s.threshold),
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// msgText 获取文本消息元素的文本
+func msgText(body *types.MsgBody) (string, bool) {
+	if body == nil || body.MsgType != TIMTextElem {
+		return "", false
+	}
+
+	switch content := body.MsgContent.(type) {
+	case map[string]interface{}:
+		text, ok := content["Text"].(string)
+		return text, ok
+	case types.MsgTextContent:
+		return content.Text, true
+	case *types.MsgTextContent:
+		return content.Text, content != nil
+	}
+
+	return "", false
+}
@@ -0,0 +1,177 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/19 14:00
+ * @Desc: 内容审核测试
+ */
+
+package callback
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/default-yarns/tencent-im/internal/types"
+)
+
+func newTextMessage(userId string, texts ...string) *ModerationMessage {
+	msg := &ModerationMessage{Event: EventBeforeGroupMessageSend, FromUserId: userId, GroupId: "g1"}
+	for _, text := range texts {
+		msg.MsgBody = append(msg.MsgBody, &types.MsgBody{MsgType: TIMTextElem, MsgContent: types.MsgTextContent{Text: text}})
+	}
+
+	return msg
+}
+
+func textOf(t *testing.T, decision *ModerationDecision, i int) string {
+	text, ok := msgText(decision.MsgBody[i])
+	if !ok {
+		t.Fatalf("message body %d is not a text element", i)
+	}
+
+	return text
+}
+
+func TestKeywordStage(t *testing.T) {
+	stage := NewKeywordStage([]string{"Bad", " ", "a.b"})
+
+	decision, err := stage.Moderate(context.Background(), newTextMessage("u1", "this is BAD"))
+	if err != nil || decision == nil || decision.Action != ModerationReject || decision.Code != ModerationRejectCode {
+		t.Fatalf("expected reject, got %+v %v", decision, err)
+	}
+
+	// 关键词按字面匹配，不作为正则表达式
+	if decision, _ = stage.Moderate(context.Background(), newTextMessage("u1", "axb", "fine")); decision != nil {
+		t.Fatalf("expected pass, got %+v", decision)
+	}
+
+	masked := NewKeywordStage([]string{"敏感"}, &TextFilterOptions{Mask: true})
+	decision, _ = masked.Moderate(context.Background(), newTextMessage("u1", "含有敏感词", "ok"))
+	if decision == nil || decision.Action != ModerationRewrite || textOf(t, decision, 0) != "含有**词" || textOf(t, decision, 1) != "ok" {
+		t.Fatalf("expected masked rewrite, got %+v", decision)
+	}
+
+	replaced := NewKeywordStage([]string{"bad"}, &TextFilterOptions{Mask: true, Replacement: "[x]"})
+	decision, _ = replaced.Moderate(context.Background(), newTextMessage("u1", "bad bad"))
+	if decision == nil || textOf(t, decision, 0) != "[x] [x]" {
+		t.Fatalf("expected replaced rewrite, got %+v", decision)
+	}
+}
+
+func TestRegexpStage(t *testing.T) {
+	if _, err := NewRegexpStage([]string{"("}); err == nil {
+		t.Fatal("invalid pattern should fail")
+	}
+
+	stage, err := NewRegexpStage([]string{`\d{11}`}, &TextFilterOptions{Code: 120001})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decision, _ := stage.Moderate(context.Background(), newTextMessage("u1", "call 13800000000"))
+	if decision == nil || decision.Action != ModerationReject || decision.Code != 120001 {
+		t.Fatalf("expected reject, got %+v", decision)
+	}
+
+	if decision, _ = stage.Moderate(context.Background(), newTextMessage("u1", "call 138")); decision != nil {
+		t.Fatalf("expected pass, got %+v", decision)
+	}
+}
+
+func TestURLAllowlistStage(t *testing.T) {
+	stage := NewURLAllowlistStage([]string{".qq.com"})
+
+	if decision, _ := stage.Moderate(context.Background(), newTextMessage("u1", "see https://im.qq.com/a and www.qq.com")); decision != nil {
+		t.Fatalf("expected pass, got %+v", decision)
+	}
+
+	decision, _ := stage.Moderate(context.Background(), newTextMessage("u1", "see https://evilqq.com/a"))
+	if decision == nil || decision.Action != ModerationReject {
+		t.Fatalf("expected reject, got %+v", decision)
+	}
+
+	masked := NewURLAllowlistStage([]string{"qq.com"}, &TextFilterOptions{Mask: true, Replacement: "[link]"})
+	decision, _ = masked.Moderate(context.Background(), newTextMessage("u1", "a http://x.com b https://qq.com"))
+	if decision == nil || decision.Action != ModerationRewrite || textOf(t, decision, 0) != "a [link] b https://qq.com" {
+		t.Fatalf("expected masked rewrite, got %+v", decision)
+	}
+}
+
+func TestRateLimitStage(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	stage := NewRateLimitStage(2, time.Minute, 10100).(*rateLimitStage)
+	stage.now = clock.Now
+
+	for i := 0; i < 2; i++ {
+		if decision, _ := stage.Moderate(context.Background(), newTextMessage("u1", "hi")); decision != nil {
+			t.Fatalf("message %d should pass, got %+v", i, decision)
+		}
+	}
+
+	decision, _ := stage.Moderate(context.Background(), newTextMessage("u1", "hi"))
+	if decision == nil || decision.Action != ModerationReject || decision.Code != 10100 {
+		t.Fatalf("expected reject, got %+v", decision)
+	}
+
+	// 不同发送者分别计数
+	if decision, _ = stage.Moderate(context.Background(), newTextMessage("u2", "hi")); decision != nil {
+		t.Fatalf("other sender should pass, got %+v", decision)
+	}
+
+	// 时间窗口过期后重新计数，过期的计数被清理
+	clock.Advance(time.Minute)
+	if decision, _ = stage.Moderate(context.Background(), newTextMessage("u1", "hi")); decision != nil {
+		t.Fatalf("message should pass after the window expires, got %+v", decision)
+	}
+
+	if _, ok := stage.counters["u2"]; ok {
+		t.Fatal("expired counter should be purged")
+	}
+}
+
+func TestModerator_Listen(t *testing.T) {
+	var audits []*ModerationAudit
+	moderator := NewModerator(&ModeratorOptions{
+		Stages: []ModerationStage{
+			NewKeywordStage([]string{"mask"}, &TextFilterOptions{Mask: true}),
+			NewKeywordStage([]string{"block"}),
+		},
+		OnAudit: func(audit *ModerationAudit) {
+			audits = append(audits, audit)
+		},
+	})
+
+	c := newTestCallback()
+	moderator.Listen(c)
+
+	// 改写后放行，应答改写后的消息体
+	rec := &recordAck{}
+	c.dispatch(context.Background(), rec, commandBeforeGroupMessageSend, EventBeforeGroupMessageSend, &BeforeGroupMessageSend{
+		MsgBody: newTextMessage("u1", "mask me").MsgBody,
+	})
+
+	resp, ok := rec.resp.(*BeforeGroupMessageSendResp)
+	if !ok || isVetoed(resp) || len(resp.MsgBody) != 1 {
+		t.Fatalf("expected rewrite response, got %+v", rec.resp)
+	}
+
+	if text, _ := msgText(resp.MsgBody[0]); text != "**** me" {
+		t.Fatalf("unexpected rewritten text: %s", text)
+	}
+
+	// 拒绝时以 ActionStatus 为 OK、ErrorCode 非0应答
+	rec = &recordAck{}
+	c.dispatch(context.Background(), rec, commandBeforeGroupMessageSend, EventBeforeGroupMessageSend, &BeforeGroupMessageSend{
+		MsgBody: newTextMessage("u1", "mask and block").MsgBody,
+	})
+
+	base := baseRespOf(rec.resp)
+	if base == nil || base.ActionStatus != ackSuccessStatus || base.ErrorCode != ModerationRejectCode {
+		t.Fatalf("expected reject response, got %+v", rec.resp)
+	}
+
+	if len(audits) != 2 || audits[1].Action != ModerationReject || audits[1].Stage != "keyword" || len(audits[1].RewriteStages) != 1 {
+		t.Fatalf("unexpected audits: %+v", audits)
+	}
+}
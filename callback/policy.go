@@ -0,0 +1,569 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 22:30
+ * @Desc: 加好友与入群审批策略
+ */
+
+package callback
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/default-yarns/tencent-im/group"
+	"github.com/default-yarns/tencent-im/internal/conv"
+	"github.com/default-yarns/tencent-im/profile"
+	"github.com/default-yarns/tencent-im/sns"
+)
+
+const (
+	PolicyFriendRejectCode = 38001 // 默认的拒绝加好友错误码，需在[38000, 39000]之间
+	PolicyGroupRejectCode  = 1     // 默认的拒绝入群错误码
+
+	defaultPolicyCacheTTL = time.Minute // 默认的资料缓存时间
+	batchGetProfilesLimit = 100         // 每次拉取资料的最大数量
+
+	responseActionReject = "Response_Action_Reject"
+)
+
+// 群成员角色
+const (
+	GroupRoleOwner  = "Owner"  // 群主
+	GroupRoleAdmin  = "Admin"  // 管理员
+	GroupRoleMember = "Member" // 普通成员
+)
+
+type (
+	// PolicyClient 审批策略所需的接口集合，IM 实例即实现了该接口
+	PolicyClient interface {
+		Profile() profile.API
+		SNS() sns.API
+		Group() group.API
+	}
+
+	// PolicyOptions 审批策略配置
+	PolicyOptions struct {
+		MaxFriends         int           // （选填）每个用户的好友上限，为0时不限制
+		MaxFriendsAttr     string        // （选填）保存用户好友上限的自定义资料字段，设置后优先使用用户资料中的上限
+		BlockedUserIds     []string      // （选填）禁止加好友与入群的用户
+		BlockedAttr        string        // （选填）标记用户被禁止加好友与入群的自定义资料字段，值为1或true时视为禁止
+		GroupCapacity      int           // （选填）群成员上限，为0时使用群组资料中的最大成员数
+		InviteOnlyGroupIds []string      // （选填）仅允许邀请入群的群组，禁止加群（DisableApply）的群组同样视为仅允许邀请
+		InviteRoles        []string      // （选填）允许邀请入群的成员角色，为空时不限制
+		FriendRejectCode   int           // （选填）拒绝加好友的错误码，默认为 PolicyFriendRejectCode
+		GroupRejectCode    int           // （选填）拒绝入群的错误码，默认为 PolicyGroupRejectCode
+		CacheTTL           time.Duration // （选填）资料缓存时间，默认为1分钟
+		FailClosed         bool          // （选填）拉取资料失败时是否拒绝，默认放行；BlockedUserIds 不依赖拉取的资料，始终生效
+	}
+
+	// Policy 加好友与入群审批策略
+	Policy struct {
+		client     PolicyClient
+		opt        PolicyOptions
+		blocked    map[string]bool
+		inviteOnly map[string]bool
+		roles      map[string]bool
+		cache      *policyCache
+	}
+
+	// policyCache 资料缓存
+	policyCache struct {
+		mu    sync.Mutex
+		ttl   time.Duration
+		items map[string]*policyCacheItem
+	}
+
+	policyCacheItem struct {
+		value    interface{}
+		expireAt time.Time
+	}
+
+	// userState 用户的审批相关资料
+	userState struct {
+		blocked       bool // 是否禁止加好友与入群
+		forbidSendOut bool // 是否被管理员禁止发起加好友请求
+		maxFriends    int  // 好友上限，为0时不限制
+	}
+)
+
+// NewPolicy 创建加好友与入群审批策略
+func NewPolicy(client PolicyClient, opt *PolicyOptions) *Policy {
+	p := &Policy{client: client}
+	if opt != nil {
+		p.opt = *opt
+	}
+
+	if p.opt.FriendRejectCode == 0 {
+		p.opt.FriendRejectCode = PolicyFriendRejectCode
+	}
+
+	if p.opt.GroupRejectCode == 0 {
+		p.opt.GroupRejectCode = PolicyGroupRejectCode
+	}
+
+	if p.opt.CacheTTL <= 0 {
+		p.opt.CacheTTL = defaultPolicyCacheTTL
+	}
+
+	p.blocked = toSet(p.opt.BlockedUserIds)
+	p.inviteOnly = toSet(p.opt.InviteOnlyGroupIds)
+	p.roles = toSet(p.opt.InviteRoles)
+	p.cache = &policyCache{ttl: p.opt.CacheTTL, items: make(map[string]*policyCacheItem)}
+
+	return p
+}
+
+// Listen 注册加好友与入群相关的 Before 类回调，并在好友关系与群成员变更后清除对应的缓存
+func (p *Policy) Listen(cb Callback) {
+	cb.OnBeforeFriendAdd(func(ctx context.Context, ack *BeforeFriendAddAck, data *BeforeFriendAdd) {
+		_ = ack.AckResp(p.EvaluateFriendAdd(data))
+	})
+
+	cb.OnBeforeFriendResponse(func(ctx context.Context, ack *BeforeFriendResponseAck, data *BeforeFriendResponse) {
+		_ = ack.AckResp(p.EvaluateFriendResponse(data))
+	})
+
+	cb.OnBeforeApplyJoinGroup(func(ctx context.Context, ack Ack, data *BeforeApplyJoinGroup) {
+		_ = ack.Ack(p.EvaluateApplyJoinGroup(data))
+	})
+
+	cb.OnBeforeInviteJoinGroup(func(ctx context.Context, ack *BeforeInviteJoinGroupAck, data *BeforeInviteJoinGroup) {
+		_ = ack.AckResp(p.EvaluateInviteJoinGroup(data))
+	})
+
+	cb.OnAfterFriendAdd(func(ctx context.Context, ack Ack, data *AfterFriendAdd) {
+		for _, pair := range data.PairList {
+			p.cache.delete(friendCountKey(pair.FromUserId), friendCountKey(pair.ToUserId))
+		}
+		_ = ack.AckSuccess(ackSuccessCode)
+	})
+
+	cb.OnAfterFriendDelete(func(ctx context.Context, ack Ack, data *AfterFriendDelete) {
+		for _, pair := range data.PairList {
+			p.cache.delete(friendCountKey(pair.FromUserId), friendCountKey(pair.ToUserId))
+		}
+		_ = ack.AckSuccess(ackSuccessCode)
+	})
+
+	cb.OnAfterNewMemberJoinGroup(func(ctx context.Context, ack Ack, data *AfterNewMemberJoinGroup) {
+		p.cache.delete(groupKey(data.GroupId))
+		_ = ack.AckSuccess(ackSuccessCode)
+	})
+
+	cb.OnAfterMemberExitGroup(func(ctx context.Context, ack Ack, data *AfterMemberExitGroup) {
+		p.cache.delete(groupKey(data.GroupId))
+		_ = ack.AckSuccess(ackSuccessCode)
+	})
+}
+
+// EvaluateFriendAdd 审批加好友请求
+// 发起方被禁止或被管理员禁止发起加好友请求时拒绝全部请求；被添加方被禁止或好友已满时拒绝该好友；发起方的剩余好友名额不足时拒绝超出名额的好友
+func (p *Policy) EvaluateFriendAdd(data *BeforeFriendAdd) *BeforeFriendAddResp {
+	resp := &BeforeFriendAddResp{BaseResp: successResp(ackSuccessCode)}
+
+	userIds := []string{data.FromUserId}
+	for _, friend := range data.Friends {
+		userIds = append(userIds, friend.ToAccount)
+	}
+	states := p.userStates(userIds)
+
+	remaining, reason := p.remainingFriends(data.FromUserId, states[data.FromUserId])
+	if state := states[data.FromUserId]; reason == "" && state != nil && state.forbidSendOut {
+		reason = fmt.Sprintf("%s is forbidden to add friends", data.FromUserId)
+	}
+
+	for _, friend := range data.Friends {
+		result := &BeforeFriendAddResult{UserId: friend.ToAccount}
+
+		if info := p.checkFriendPair(data.FromUserId, friend.ToAccount, states, reason); info != "" {
+			result.ResultCode, result.ResultInfo = p.opt.FriendRejectCode, info
+		} else if remaining == 0 {
+			result.ResultCode, result.ResultInfo = p.opt.FriendRejectCode, fmt.Sprintf("%s has reached the friend limit", data.FromUserId)
+		} else if remaining > 0 {
+			remaining--
+		}
+
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp
+}
+
+// EvaluateFriendResponse 审批加好友回应，仅审批同意类的回应，拒绝类的回应直接放行
+func (p *Policy) EvaluateFriendResponse(data *BeforeFriendResponse) *BeforeFriendResponseResp {
+	resp := &BeforeFriendResponseResp{BaseResp: successResp(ackSuccessCode)}
+
+	userIds := []string{data.FromUserId}
+	for _, friend := range data.Friends {
+		userIds = append(userIds, friend.ToAccount)
+	}
+	states := p.userStates(userIds)
+
+	remaining, reason := p.remainingFriends(data.FromUserId, states[data.FromUserId])
+
+	for _, friend := range data.Friends {
+		result := &BeforeFriendResponseResult{UserId: friend.ToAccount}
+
+		if friend.ResponseAction != responseActionReject {
+			if info := p.checkFriendPair(data.FromUserId, friend.ToAccount, states, reason); info != "" {
+				result.ResultCode, result.ResultInfo = p.opt.FriendRejectCode, info
+			} else if remaining == 0 {
+				result.ResultCode, result.ResultInfo = p.opt.FriendRejectCode, fmt.Sprintf("%s has reached the friend limit", data.FromUserId)
+			} else if remaining > 0 {
+				remaining--
+			}
+		}
+
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp
+}
+
+// EvaluateApplyJoinGroup 审批申请入群，申请者被禁止、群组仅允许邀请或群成员已满时拒绝
+func (p *Policy) EvaluateApplyJoinGroup(data *BeforeApplyJoinGroup) BaseResp {
+	states := p.userStates([]string{data.RequestorUserId})
+	if state := states[data.RequestorUserId]; state == nil {
+		if p.opt.FailClosed {
+			return rejectResp(p.opt.GroupRejectCode, "failed to get the profile of requestor")
+		}
+	} else if state.blocked {
+		return rejectResp(p.opt.GroupRejectCode, fmt.Sprintf("%s is blocked", data.RequestorUserId))
+	}
+
+	if p.inviteOnly[data.GroupId] {
+		return rejectResp(p.opt.GroupRejectCode, "the group is invite only")
+	}
+
+	g, err := p.getGroup(data.GroupId)
+	if err != nil {
+		if p.opt.FailClosed {
+			return rejectResp(p.opt.GroupRejectCode, err.Error())
+		}
+		return successResp(ackSuccessCode)
+	}
+
+	if g.GetApplyJoinOption() == string(group.ApplyJoinOptionDisableApply) {
+		return rejectResp(p.opt.GroupRejectCode, "the group is invite only")
+	}
+
+	if p.groupRemaining(g) <= 0 {
+		return rejectResp(p.opt.GroupRejectCode, "the group is full")
+	}
+
+	return successResp(ackSuccessCode)
+}
+
+// EvaluateInviteJoinGroup 审批拉人入群
+// 操作者的角色无权邀请时拒绝全部成员；被禁止的成员及超出群成员上限的成员加入拒绝列表
+func (p *Policy) EvaluateInviteJoinGroup(data *BeforeInviteJoinGroup) *BeforeInviteJoinGroupResp {
+	resp := &BeforeInviteJoinGroupResp{BaseResp: successResp(ackSuccessCode)}
+
+	if len(p.roles) > 0 {
+		role, err := p.getRole(data.GroupId, data.OperatorUserId)
+		if err != nil {
+			if p.opt.FailClosed {
+				resp.BaseResp = rejectResp(p.opt.GroupRejectCode, err.Error())
+				return resp
+			}
+		} else if !p.roles[role] {
+			resp.BaseResp = rejectResp(p.opt.GroupRejectCode, fmt.Sprintf("the role %s is not allowed to invite", role))
+			return resp
+		}
+	}
+
+	userIds := make([]string, 0, len(data.MemberList))
+	for _, member := range data.MemberList {
+		userIds = append(userIds, member.UserId)
+	}
+	states := p.userStates(userIds)
+
+	remaining := -1
+	if g, err := p.getGroup(data.GroupId); err == nil {
+		remaining = p.groupRemaining(g)
+	} else if p.opt.FailClosed {
+		resp.BaseResp = rejectResp(p.opt.GroupRejectCode, err.Error())
+		return resp
+	}
+
+	for _, userId := range userIds {
+		state := states[userId]
+		switch {
+		case state == nil && p.opt.FailClosed, state != nil && state.blocked, remaining == 0:
+			resp.RefusedMemberUserIds = append(resp.RefusedMemberUserIds, userId)
+		default:
+			if remaining > 0 {
+				remaining--
+			}
+		}
+	}
+
+	return resp
+}
+
+// checkFriendPair 检查好友双方是否允许建立好友关系，返回拒绝原因
+func (p *Policy) checkFriendPair(fromUserId, toUserId string, states map[string]*userState, fromReason string) string {
+	if fromReason != "" {
+		return fromReason
+	}
+
+	to := states[toUserId]
+	if to == nil {
+		if p.opt.FailClosed {
+			return fmt.Sprintf("failed to get the profile of %s", toUserId)
+		}
+		return ""
+	}
+
+	if to.blocked {
+		return fmt.Sprintf("%s is blocked", toUserId)
+	}
+
+	if remaining, _ := p.remainingFriends(toUserId, to); remaining == 0 {
+		return fmt.Sprintf("%s has reached the friend limit", toUserId)
+	}
+
+	return ""
+}
+
+// remainingFriends 获取用户的剩余好友名额，-1表示不限制；用户被禁止时返回拒绝原因
+func (p *Policy) remainingFriends(userId string, state *userState) (int, string) {
+	if state == nil {
+		if p.opt.FailClosed {
+			return 0, fmt.Sprintf("failed to get the profile of %s", userId)
+		}
+		return -1, ""
+	}
+
+	if state.blocked {
+		return 0, fmt.Sprintf("%s is blocked", userId)
+	}
+
+	if state.maxFriends <= 0 {
+		return -1, ""
+	}
+
+	count, err := p.friendCount(userId)
+	if err != nil {
+		if p.opt.FailClosed {
+			return 0, err.Error()
+		}
+		return -1, ""
+	}
+
+	if remaining := state.maxFriends - count; remaining > 0 {
+		return remaining, ""
+	}
+
+	return 0, ""
+}
+
+// userStates 批量获取用户的审批相关资料，获取失败的用户不在结果中；
+// 静态禁止名单中的用户无需拉取资料，拉取资料失败时同样视为禁止
+func (p *Policy) userStates(userIds []string) map[string]*userState {
+	states := make(map[string]*userState, len(userIds))
+
+	var attrs []string
+	if p.opt.MaxFriendsAttr != "" {
+		attrs = append(attrs, p.opt.MaxFriendsAttr)
+	}
+	if p.opt.BlockedAttr != "" {
+		attrs = append(attrs, p.opt.BlockedAttr)
+	}
+	attrs = append(attrs, profile.StandardAttrAdminForbidType)
+
+	seen := make(map[string]bool, len(userIds))
+	missing := make([]string, 0, len(userIds))
+	for _, userId := range userIds {
+		if seen[userId] {
+			continue
+		}
+		seen[userId] = true
+
+		if p.blocked[userId] {
+			states[userId] = &userState{blocked: true}
+			continue
+		}
+
+		if v, ok := p.cache.get(profileKey(userId)); ok {
+			states[userId] = v.(*userState)
+		} else {
+			missing = append(missing, userId)
+		}
+	}
+
+	for i := 0; i < len(missing); i += batchGetProfilesLimit {
+		end := i + batchGetProfilesLimit
+		if end > len(missing) {
+			end = len(missing)
+		}
+
+		p.fetchUserStates(states, missing[i:end], attrs)
+	}
+
+	return states
+}
+
+// fetchUserStates 拉取一批用户的资料并写入审批状态，拉取失败时跳过该批用户
+func (p *Policy) fetchUserStates(states map[string]*userState, userIds []string, attrs []string) {
+	profiles, err := p.client.Profile().GetProfiles(userIds, attrs)
+	if err != nil {
+		return
+	}
+
+	for _, pf := range profiles {
+		if pf.GetError() != nil {
+			continue
+		}
+
+		userId := pf.GetUserId()
+		state := &userState{maxFriends: p.opt.MaxFriends}
+
+		if v, ok := pf.GetAttr(profile.StandardAttrAdminForbidType); ok {
+			state.forbidSendOut = conv.String(v) == string(profile.AdminForbidTypeSendOut)
+		}
+
+		if p.opt.BlockedAttr != "" {
+			if v, ok := pf.GetAttr(p.opt.BlockedAttr); ok {
+				if s := conv.String(v); s == "1" || s == "true" {
+					state.blocked = true
+				}
+			}
+		}
+
+		if p.opt.MaxFriendsAttr != "" {
+			if v, ok := pf.GetAttr(p.opt.MaxFriendsAttr); ok {
+				if n, err := strconv.Atoi(conv.String(v)); err == nil && n > 0 {
+					state.maxFriends = n
+				}
+			}
+		}
+
+		states[userId] = state
+		p.cache.set(profileKey(userId), state)
+	}
+}
+
+// friendCount 获取用户的好友数
+func (p *Policy) friendCount(userId string) (int, error) {
+	if v, ok := p.cache.get(friendCountKey(userId)); ok {
+		return v.(int), nil
+	}
+
+	ret, err := p.client.SNS().FetchFriends(userId, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	p.cache.set(friendCountKey(userId), ret.Total)
+
+	return ret.Total, nil
+}
+
+// getGroup 获取群组资料
+func (p *Policy) getGroup(groupId string) (*group.Group, error) {
+	if v, ok := p.cache.get(groupKey(groupId)); ok {
+		return v.(*group.Group), nil
+	}
+
+	g, err := p.client.Group().GetGroup(groupId)
+	if err != nil {
+		return nil, err
+	}
+
+	p.cache.set(groupKey(groupId), g)
+
+	return g, nil
+}
+
+// getRole 获取成员在群组中的角色
+func (p *Policy) getRole(groupId, userId string) (string, error) {
+	key := "role:" + groupId + dedupKeySplitter + userId
+	if v, ok := p.cache.get(key); ok {
+		return v.(string), nil
+	}
+
+	roles, err := p.client.Group().GetRolesInGroup(groupId, []string{userId})
+	if err != nil {
+		return "", err
+	}
+
+	role := roles[userId]
+	p.cache.set(key, role)
+
+	return role, nil
+}
+
+// groupRemaining 获取群组的剩余成员名额
+func (p *Policy) groupRemaining(g *group.Group) int {
+	capacity := int(g.GetMaxMemberNum())
+	if p.opt.GroupCapacity > 0 && (capacity == 0 || p.opt.GroupCapacity < capacity) {
+		capacity = p.opt.GroupCapacity
+	}
+
+	if capacity <= 0 {
+		return -1
+	}
+
+	if remaining := capacity - int(g.GetMemberNum()); remaining > 0 {
+		return remaining
+	}
+
+	return 0
+}
+
+func (c *policyCache) get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	if time.Now().After(item.expireAt) {
+		delete(c.items, key)
+		return nil, false
+	}
+
+	return item.value, true
+}
+
+func (c *policyCache) set(key string, value interface{}) {
+	c.mu.Lock()
+	c.items[key] = &policyCacheItem{value: value, expireAt: time.Now().Add(c.ttl)}
+	c.mu.Unlock()
+}
+
+func (c *policyCache) delete(keys ...string) {
+	c.mu.Lock()
+	for _, key := range keys {
+		delete(c.items, key)
+	}
+	c.mu.Unlock()
+}
+
+func profileKey(userId string) string {
+	return "profile:" + userId
+}
+
+func friendCountKey(userId string) string {
+	return "friends:" + userId
+}
+
+func groupKey(groupId string) string {
+	return "group:" + groupId
+}
+
+func toSet(items []string) map[string]bool {
+	set := make(map[string]bool, len(items))
+	for _, item := range items {
+		set[item] = true
+	}
+
+	return set
+}
@@ -0,0 +1,146 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/19 14:30
+ * @Desc: 加好友与入群审批策略测试
+ */
+
+package callback
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/default-yarns/tencent-im/group"
+	"github.com/default-yarns/tencent-im/profile"
+	"github.com/default-yarns/tencent-im/sns"
+)
+
+var errProfileUnavailable = errors.New("profile unavailable")
+
+type (
+	stubPolicyClient struct{}
+
+	failingProfileAPI struct {
+		profile.API
+	}
+
+	batchPolicyClient struct {
+		stubPolicyClient
+		api *recordingProfileAPI
+	}
+
+	recordingProfileAPI struct {
+		profile.API
+		batches []int
+	}
+
+	stubGroupAPI struct {
+		group.API
+	}
+)
+
+func (stubPolicyClient) Profile() profile.API { return failingProfileAPI{} }
+func (stubPolicyClient) SNS() sns.API         { return nil }
+func (stubPolicyClient) Group() group.API     { return stubGroupAPI{} }
+
+func (failingProfileAPI) GetProfiles(userIds []string, attrs []string) ([]*profile.Profile, error) {
+	return nil, errProfileUnavailable
+}
+
+func (c batchPolicyClient) Profile() profile.API { return c.api }
+
+func (a *recordingProfileAPI) GetProfiles(userIds []string, attrs []string) ([]*profile.Profile, error) {
+	a.batches = append(a.batches, len(userIds))
+
+	profiles := make([]*profile.Profile, 0, len(userIds))
+	for _, userId := range userIds {
+		profiles = append(profiles, profile.NewProfile(userId))
+	}
+
+	return profiles, nil
+}
+
+func (stubGroupAPI) GetGroup(groupId string, filter ...*group.Filter) (*group.Group, error) {
+	g := group.NewGroup(groupId)
+	g.SetMaxMemberNum(100)
+	return g, nil
+}
+
+func TestPolicy_BlockedWithoutProfile(t *testing.T) {
+	policy := NewPolicy(stubPolicyClient{}, &PolicyOptions{BlockedUserIds: []string{"blocked"}})
+
+	resp := policy.EvaluateApplyJoinGroup(&BeforeApplyJoinGroup{GroupId: "g1", RequestorUserId: "blocked"})
+	if resp.ActionStatus != ackSuccessStatus || resp.ErrorCode != PolicyGroupRejectCode {
+		t.Fatalf("blocked user should be rejected, got %+v", resp)
+	}
+
+	// 未开启 FailClosed 时，拉取资料失败的其他用户放行
+	if resp = policy.EvaluateApplyJoinGroup(&BeforeApplyJoinGroup{GroupId: "g1", RequestorUserId: "other"}); resp.ErrorCode != ackSuccessCode {
+		t.Fatalf("other user should pass, got %+v", resp)
+	}
+
+	friendResp := policy.EvaluateFriendAdd(&BeforeFriendAdd{
+		FromUserId: "other",
+		Friends: []struct {
+			ToAccount  string `json:"To_Account"`
+			Remark     string `json:"Remark"`
+			GroupName  string `json:"GroupName"`
+			AddSource  string `json:"AddSource"`
+			AddWording string `json:"AddWording"`
+		}{{ToAccount: "blocked"}, {ToAccount: "friend"}},
+	})
+	if len(friendResp.Results) != 2 || friendResp.Results[0].ResultCode != PolicyFriendRejectCode || friendResp.Results[1].ResultCode != ackSuccessCode {
+		t.Fatalf("unexpected friend add results: %+v %+v", friendResp.Results[0], friendResp.Results[1])
+	}
+
+	inviteResp := policy.EvaluateInviteJoinGroup(&BeforeInviteJoinGroup{
+		GroupId: "g1",
+		MemberList: []struct {
+			UserId string `json:"Member_Account"`
+		}{{UserId: "blocked"}, {UserId: "other"}},
+	})
+	if len(inviteResp.RefusedMemberUserIds) != 1 || inviteResp.RefusedMemberUserIds[0] != "blocked" {
+		t.Fatalf("unexpected refused members: %v", inviteResp.RefusedMemberUserIds)
+	}
+}
+
+func TestPolicy_FailClosed(t *testing.T) {
+	policy := NewPolicy(stubPolicyClient{}, &PolicyOptions{BlockedUserIds: []string{"blocked"}, FailClosed: true})
+
+	resp := policy.EvaluateApplyJoinGroup(&BeforeApplyJoinGroup{GroupId: "g1", RequestorUserId: "other"})
+	if resp.ErrorCode != PolicyGroupRejectCode || resp.ErrorInfo != "failed to get the profile of requestor" {
+		t.Fatalf("expected fail closed rejection, got %+v", resp)
+	}
+
+	if resp = policy.EvaluateApplyJoinGroup(&BeforeApplyJoinGroup{GroupId: "g1", RequestorUserId: "blocked"}); resp.ErrorInfo != "blocked is blocked" {
+		t.Fatalf("expected blocked rejection, got %+v", resp)
+	}
+}
+
+func TestPolicy_BatchProfiles(t *testing.T) {
+	api := &recordingProfileAPI{}
+	policy := NewPolicy(batchPolicyClient{api: api}, &PolicyOptions{BlockedUserIds: []string{"blocked"}, FailClosed: true})
+
+	data := &BeforeInviteJoinGroup{GroupId: "g1"}
+	data.MemberList = append(data.MemberList, struct {
+		UserId string `json:"Member_Account"`
+	}{UserId: "blocked"})
+	for i := 0; i < 250; i++ {
+		data.MemberList = append(data.MemberList, struct {
+			UserId string `json:"Member_Account"`
+		}{UserId: "u" + strconv.Itoa(i)})
+	}
+
+	// 每批最多拉取100个用户的资料，静态禁止名单中的用户无需拉取
+	resp := policy.EvaluateInviteJoinGroup(data)
+	if len(api.batches) != 3 || api.batches[0] != 100 || api.batches[1] != 100 || api.batches[2] != 50 {
+		t.Fatalf("unexpected batches: %v", api.batches)
+	}
+
+	// 群组最多容纳100人，超出部分拒绝
+	if len(resp.RefusedMemberUserIds) != 151 || resp.RefusedMemberUserIds[0] != "blocked" {
+		t.Fatalf("unexpected refused members: %d", len(resp.RefusedMemberUserIds))
+	}
+}
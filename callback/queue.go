@@ -0,0 +1,423 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 20:00
+ * @Desc: 回调任务队列
+ */
+
+package callback
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	spillFileName       = "callback-queue.jsonl"  // 溢出文件名
+	spillOffsetFileName = "callback-queue.offset" // 溢出文件读取位置的文件名
+)
+
+var (
+	ErrQueueFull   = errors.New("callback queue is full")
+	ErrQueueClosed = errors.New("callback queue is closed")
+)
+
+type (
+	// Job 回调任务，保存回调命令与原始请求体，便于持久化后重新解析处理
+	Job struct {
+		Id         string          `json:"id"`                  // 任务ID
+		Command    string          `json:"command"`             // 回调命令
+		Body       json.RawMessage `json:"body"`                // 原始请求体
+		Attempts   int             `json:"attempts"`            // 已失败的处理次数
+		LastError  string          `json:"lastError,omitempty"` // 最后一次处理失败的原因
+		EnqueuedAt int64           `json:"enqueuedAt"`          // 入队时间，单位为毫秒
+	}
+
+	// Queue 回调任务队列，可替换为 Redis、Kafka 等持久化队列
+	Queue interface {
+		// Push 推入任务
+		Push(job *Job) error
+		// Pop 取出任务，队列为空时阻塞直到有新任务、上下文结束或队列关闭
+		Pop(ctx context.Context) (*Job, error)
+		// Close 关闭队列
+		Close() error
+	}
+
+	// DroppedError 关闭队列时丢弃了未处理的任务
+	DroppedError struct {
+		Dropped int   // 丢弃的任务数
+		Err     error // 导致任务未处理完成的原因，如停止异步处理时上下文已结束
+	}
+
+	// memoryQueue 内存队列，超出容量的任务溢出到磁盘
+	memoryQueue struct {
+		mu       sync.Mutex
+		jobs     []*Job
+		capacity int
+		spill    *spillFile
+		notify   chan struct{}
+		done     chan struct{}
+		drained  chan struct{}
+		draining bool
+		closed   bool
+		inflight map[*Job]*spillRead
+	}
+
+	// spillFile 磁盘溢出文件，任务以 JSON Lines 格式追加写入
+	spillFile struct {
+		path       string
+		offsetPath string
+		writer     *os.File
+		reader     *os.File
+		buffer     *bufio.Reader
+		offset     int64        // 已读取的位置
+		committed  int64        // 已处理完成的位置，之前的任务均已处理完成
+		size       int64        // 文件大小
+		reads      []*spillRead // 已读取但尚未推进处理完成位置的任务，按读取顺序排列
+	}
+
+	// spillRead 从溢出文件读取的任务
+	spillRead struct {
+		end     int64 // 任务记录的结束位置
+		handled bool  // 是否已处理完成
+	}
+)
+
+// NewMemoryQueue 创建内存队列
+// capacity 为内存中最多缓存的任务数；spillDir 不为空时，超出容量的任务溢出到该目录下的文件中，
+// 关闭队列时内存中未处理的任务也会写入溢出文件，下次创建队列时继续处理；
+// 从溢出文件取出的任务在异步处理完成后才从文件中清除，关闭队列时尚未处理完成的任务下次创建队列时重新处理；
+// spillDir 为空时，队列满后推入任务返回 ErrQueueFull，关闭队列时丢弃内存中未处理的任务并返回 *DroppedError
+func NewMemoryQueue(capacity int, spillDir ...string) (Queue, error) {
+	if capacity <= 0 {
+		capacity = 1024
+	}
+
+	q := &memoryQueue{
+		jobs:     make([]*Job, 0, capacity),
+		capacity: capacity,
+		notify:   make(chan struct{}, 1),
+		done:     make(chan struct{}),
+		drained:  make(chan struct{}),
+		inflight: make(map[*Job]*spillRead),
+	}
+
+	if len(spillDir) > 0 && spillDir[0] != "" {
+		spill, err := openSpillFile(spillDir[0])
+		if err != nil {
+			return nil, err
+		}
+		q.spill = spill
+	}
+
+	return q, nil
+}
+
+// Push 推入任务，溢出文件中有积压的任务时新任务同样写入溢出文件以保证顺序
+func (q *memoryQueue) Push(job *Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrQueueClosed
+	}
+
+	if q.spill != nil && (q.spill.pending() || len(q.jobs) >= q.capacity) {
+		if err := q.spill.write(job); err != nil {
+			return err
+		}
+	} else if len(q.jobs) >= q.capacity {
+		return ErrQueueFull
+	} else {
+		q.jobs = append(q.jobs, job)
+	}
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// Pop 取出任务，排空模式下队列为空时不再阻塞，返回 ErrQueueClosed
+func (q *memoryQueue) Pop(ctx context.Context) (*Job, error) {
+	for {
+		if job, err := q.pop(); job != nil || err != nil {
+			return job, err
+		}
+
+		q.mu.Lock()
+		draining := q.draining
+		q.mu.Unlock()
+
+		if draining {
+			return nil, ErrQueueClosed
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-q.done:
+			return nil, ErrQueueClosed
+		case <-q.drained:
+		case <-q.notify:
+		}
+	}
+}
+
+// drain 进入排空模式，仍可推入与取出任务，队列为空时取出任务不再阻塞
+func (q *memoryQueue) drain() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !q.draining {
+		q.draining = true
+		close(q.drained)
+	}
+}
+
+func (q *memoryQueue) pop() (*Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return nil, ErrQueueClosed
+	}
+
+	if len(q.jobs) > 0 {
+		job := q.jobs[0]
+		q.jobs[0] = nil
+		q.jobs = q.jobs[1:]
+		return job, nil
+	}
+
+	if q.spill != nil && q.spill.pending() {
+		job, read, err := q.spill.read()
+		if job != nil {
+			q.inflight[job] = read
+		}
+		return job, err
+	}
+
+	return nil, nil
+}
+
+// ack 确认任务已处理完成，从溢出文件读取的任务在确认前不会从文件中清除
+func (q *memoryQueue) ack(job *Job) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	read, ok := q.inflight[job]
+	if !ok {
+		return
+	}
+	delete(q.inflight, job)
+	read.handled = true
+
+	if !q.closed {
+		// 清空失败时保留文件，下次确认时重试
+		_ = q.spill.commit()
+	}
+}
+
+// Close 关闭队列，内存中未处理的任务写入溢出文件，未配置溢出目录时丢弃并返回 *DroppedError
+func (q *memoryQueue) Close() (err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return nil
+	}
+	q.closed = true
+	close(q.done)
+
+	if q.spill == nil {
+		if dropped := len(q.jobs); dropped > 0 {
+			err = &DroppedError{Dropped: dropped}
+		}
+		q.jobs = nil
+		return
+	}
+
+	for _, job := range q.jobs {
+		if err = q.spill.write(job); err != nil {
+			break
+		}
+	}
+	q.jobs = nil
+
+	if e := q.spill.close(); err == nil {
+		err = e
+	}
+
+	return
+}
+
+// Error 错误信息
+func (e *DroppedError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("callback queue closed with %d jobs dropped: %s", e.Dropped, e.Err)
+	}
+
+	return fmt.Sprintf("callback queue closed with %d jobs dropped", e.Dropped)
+}
+
+// Unwrap 获取导致任务未处理完成的原因
+func (e *DroppedError) Unwrap() error {
+	return e.Err
+}
+
+// openSpillFile 打开溢出文件，并从上次记录的读取位置继续读取
+func openSpillFile(dir string) (*spillFile, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	f := &spillFile{
+		path:       filepath.Join(dir, spillFileName),
+		offsetPath: filepath.Join(dir, spillOffsetFileName),
+	}
+
+	writer, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := writer.Stat()
+	if err != nil {
+		_ = writer.Close()
+		return nil, err
+	}
+
+	reader, err := os.Open(f.path)
+	if err != nil {
+		_ = writer.Close()
+		return nil, err
+	}
+
+	f.writer, f.reader, f.size = writer, reader, info.Size()
+
+	if data, err := ioutil.ReadFile(f.offsetPath); err == nil {
+		if offset, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64); err == nil && offset <= f.size {
+			f.offset = offset
+		}
+	}
+
+	f.committed = f.offset
+
+	if _, err = reader.Seek(f.offset, 0); err != nil {
+		_ = f.close()
+		return nil, err
+	}
+	f.buffer = bufio.NewReader(reader)
+
+	return f, nil
+}
+
+// pending 是否有未读取的任务
+func (f *spillFile) pending() bool {
+	return f.offset < f.size
+}
+
+// write 写入任务
+func (f *spillFile) write(job *Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	n, err := f.writer.Write(append(data, '\n'))
+	f.size += int64(n)
+
+	return err
+}
+
+// read 读取任务，跳过无法解析的损坏记录，没有可读取的任务时返回 nil
+func (f *spillFile) read() (*Job, *spillRead, error) {
+	for f.pending() {
+		line, err := f.buffer.ReadBytes('\n')
+		f.offset += int64(len(line))
+		if err != nil {
+			// 文件末尾不完整的记录或读取失败时跳过剩余内容
+			f.offset = f.size
+			f.reads = append(f.reads, &spillRead{end: f.size, handled: true})
+			if err != io.EOF {
+				return nil, nil, err
+			}
+			continue
+		}
+
+		read := &spillRead{end: f.offset}
+		f.reads = append(f.reads, read)
+
+		job := &Job{}
+		if err = json.Unmarshal(line, job); err == nil {
+			return job, read, nil
+		}
+
+		read.handled = true
+	}
+
+	return nil, nil, f.commit()
+}
+
+// commit 推进处理完成的位置，全部任务处理完成后清空文件
+func (f *spillFile) commit() error {
+	for len(f.reads) > 0 && f.reads[0].handled {
+		f.committed = f.reads[0].end
+		f.reads = f.reads[1:]
+	}
+
+	if f.size > 0 && f.committed >= f.size {
+		return f.reset()
+	}
+
+	return nil
+}
+
+// reset 清空已全部读取的溢出文件
+func (f *spillFile) reset() error {
+	if err := f.writer.Truncate(0); err != nil {
+		return err
+	}
+
+	if _, err := f.reader.Seek(0, 0); err != nil {
+		return err
+	}
+
+	f.buffer.Reset(f.reader)
+	f.offset, f.committed, f.size = 0, 0, 0
+
+	if err := os.Remove(f.offsetPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// close 记录处理完成的位置并关闭文件，已读取但尚未处理完成的任务下次打开时重新读取；
+// 进程异常退出时未记录的位置会导致部分任务被重复处理
+func (f *spillFile) close() error {
+	err := ioutil.WriteFile(f.offsetPath, []byte(strconv.FormatInt(f.committed, 10)), 0644)
+
+	if e := f.writer.Close(); err == nil {
+		err = e
+	}
+
+	if e := f.reader.Close(); err == nil {
+		err = e
+	}
+
+	return err
+}
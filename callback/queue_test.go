@@ -0,0 +1,173 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/19 15:00
+ * @Desc: 回调任务队列测试
+ */
+
+package callback
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+// popIds 取出并确认任务
+func popIds(t *testing.T, q Queue, n int) []string {
+	ids := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		job, err := q.Pop(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		q.(acker).ack(job)
+		ids = append(ids, job.Id)
+	}
+
+	return ids
+}
+
+func pushJobs(t *testing.T, q Queue, from, to int) {
+	for i := from; i <= to; i++ {
+		if err := q.Push(&Job{Id: strconv.Itoa(i), Command: commandAfterFriendAdd}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestMemoryQueue_Spill(t *testing.T) {
+	dir := t.TempDir()
+
+	q, err := NewMemoryQueue(2, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 超出容量的任务溢出到磁盘，取出顺序与推入顺序一致
+	pushJobs(t, q, 1, 5)
+	if ids := popIds(t, q, 3); ids[0] != "1" || ids[1] != "2" || ids[2] != "3" {
+		t.Fatalf("unexpected order: %v", ids)
+	}
+
+	// 溢出文件中有积压时新任务同样写入溢出文件
+	pushJobs(t, q, 6, 6)
+
+	if err = q.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = q.Pop(context.Background()); err != ErrQueueClosed {
+		t.Fatalf("expected queue closed, got %v", err)
+	}
+
+	// 重新打开队列后继续处理未取出的任务
+	if q, err = NewMemoryQueue(2, dir); err != nil {
+		t.Fatal(err)
+	}
+	defer q.Close()
+
+	if ids := popIds(t, q, 3); ids[0] != "4" || ids[1] != "5" || ids[2] != "6" {
+		t.Fatalf("unexpected order after reopen: %v", ids)
+	}
+}
+
+func TestMemoryQueue_PersistOnClose(t *testing.T) {
+	dir := t.TempDir()
+
+	q, err := NewMemoryQueue(10, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 关闭队列时内存中的任务写入溢出文件
+	pushJobs(t, q, 1, 2)
+	if err = q.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if q, err = NewMemoryQueue(10, dir); err != nil {
+		t.Fatal(err)
+	}
+	defer q.Close()
+
+	if ids := popIds(t, q, 2); ids[0] != "1" || ids[1] != "2" {
+		t.Fatalf("unexpected jobs after reopen: %v", ids)
+	}
+}
+
+func TestMemoryQueue_WithoutSpill(t *testing.T) {
+	q, err := NewMemoryQueue(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pushJobs(t, q, 1, 2)
+	if err = q.Push(&Job{Id: "3"}); err != ErrQueueFull {
+		t.Fatalf("expected queue full, got %v", err)
+	}
+
+	var de *DroppedError
+	if err = q.Close(); !errors.As(err, &de) || de.Dropped != 2 {
+		t.Fatalf("expected 2 dropped jobs, got %v", err)
+	}
+}
+
+func TestMemoryQueue_RedeliverUnacked(t *testing.T) {
+	dir := t.TempDir()
+
+	q, err := NewMemoryQueue(1, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 任务2、3溢出到磁盘，取出任务2后未确认即关闭队列
+	pushJobs(t, q, 1, 3)
+	popIds(t, q, 1)
+	if job, err := q.Pop(context.Background()); err != nil || job.Id != "2" {
+		t.Fatalf("unexpected job: %+v %v", job, err)
+	}
+
+	if err = q.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 未确认的任务在重新打开队列后再次取出
+	if q, err = NewMemoryQueue(1, dir); err != nil {
+		t.Fatal(err)
+	}
+
+	if ids := popIds(t, q, 2); ids[0] != "2" || ids[1] != "3" {
+		t.Fatalf("unexpected jobs after reopen: %v", ids)
+	}
+
+	// 全部任务确认后清空溢出文件
+	if info, err := os.Stat(filepath.Join(dir, spillFileName)); err != nil || info.Size() != 0 {
+		t.Fatalf("expected spill file truncated, got %v %v", info, err)
+	}
+
+	_ = q.Close()
+}
+
+func TestMemoryQueue_SkipCorrupt(t *testing.T) {
+	dir := t.TempDir()
+
+	data := "{\"id\":\"1\"}\nnot json\n{\"id\":\"2\"}\n"
+	if err := ioutil.WriteFile(filepath.Join(dir, spillFileName), []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	q, err := NewMemoryQueue(1, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q.Close()
+
+	if ids := popIds(t, q, 2); ids[0] != "1" || ids[1] != "2" {
+		t.Fatalf("unexpected jobs: %v", ids)
+	}
+}
@@ -0,0 +1,42 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 15:00
+ * @Desc: 机器人账号识别
+ */
+
+package callback
+
+import (
+	"github.com/default-yarns/tencent-im/internal/enum"
+)
+
+// IsFromRobot 消息发送者是否为机器人
+func (m *BeforePrivateMessageSend) IsFromRobot() bool {
+	return enum.IsRobot(m.FromUserId)
+}
+
+// IsToRobot 消息接收者是否为机器人
+func (m *BeforePrivateMessageSend) IsToRobot() bool {
+	return enum.IsRobot(m.ToUserId)
+}
+
+// IsFromRobot 消息发送者是否为机器人
+func (m *AfterPrivateMessageSend) IsFromRobot() bool {
+	return enum.IsRobot(m.FromUserId)
+}
+
+// IsToRobot 消息接收者是否为机器人
+func (m *AfterPrivateMessageSend) IsToRobot() bool {
+	return enum.IsRobot(m.ToUserId)
+}
+
+// IsFromRobot 消息发送者是否为机器人
+func (m *BeforeGroupMessageSend) IsFromRobot() bool {
+	return enum.IsRobot(m.FromUserId)
+}
+
+// IsFromRobot 消息发送者是否为机器人
+func (m *AfterGroupMessageSend) IsFromRobot() bool {
+	return enum.IsRobot(m.FromUserId)
+}
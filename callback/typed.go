@@ -0,0 +1,451 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 19:00
+ * @Desc: 强类型的回调事件注册
+ */
+
+package callback
+
+import "context"
+
+type (
+	// TypedRegistry 强类型的回调事件注册，处理器直接接收具体的回调结构，
+	// 需要自定义应答的 Before 类回调会收到专用的应答器，只能应答对应的应答结构
+	TypedRegistry interface {
+		// OnStateChange 注册状态变更回调
+		OnStateChange(handler func(ctx context.Context, ack Ack, data *StateChange))
+		// OnBeforeFriendAdd 注册添加好友之前回调
+		OnBeforeFriendAdd(handler func(ctx context.Context, ack *BeforeFriendAddAck, data *BeforeFriendAdd))
+		// OnBeforeFriendResponse 注册添加好友回应之前回调
+		OnBeforeFriendResponse(handler func(ctx context.Context, ack *BeforeFriendResponseAck, data *BeforeFriendResponse))
+		// OnAfterFriendAdd 注册添加好友之后回调
+		OnAfterFriendAdd(handler func(ctx context.Context, ack Ack, data *AfterFriendAdd))
+		// OnAfterFriendDelete 注册删除好友之后回调
+		OnAfterFriendDelete(handler func(ctx context.Context, ack Ack, data *AfterFriendDelete))
+		// OnAfterBlacklistAdd 注册添加黑名单之后回调
+		OnAfterBlacklistAdd(handler func(ctx context.Context, ack Ack, data *AfterBlacklistAdd))
+		// OnAfterBlacklistDelete 注册删除黑名单之后回调
+		OnAfterBlacklistDelete(handler func(ctx context.Context, ack Ack, data *AfterBlacklistDelete))
+		// OnBeforePrivateMessageSend 注册发单聊消息之前回调
+		OnBeforePrivateMessageSend(handler func(ctx context.Context, ack *BeforePrivateMessageSendAck, data *BeforePrivateMessageSend))
+		// OnAfterPrivateMessageSend 注册发单聊消息之后回调
+		OnAfterPrivateMessageSend(handler func(ctx context.Context, ack Ack, data *AfterPrivateMessageSend))
+		// OnAfterPrivateMessageReport 注册单聊消息已读上报后回调
+		OnAfterPrivateMessageReport(handler func(ctx context.Context, ack Ack, data *AfterPrivateMessageReport))
+		// OnAfterPrivateMessageRevoke 注册单聊消息撤回后回调
+		OnAfterPrivateMessageRevoke(handler func(ctx context.Context, ack Ack, data *AfterPrivateMessageRevoke))
+		// OnBeforeGroupCreate 注册创建群组之前回调
+		OnBeforeGroupCreate(handler func(ctx context.Context, ack Ack, data *BeforeGroupCreate))
+		// OnAfterGroupCreate 注册创建群组之后回调
+		OnAfterGroupCreate(handler func(ctx context.Context, ack Ack, data *AfterGroupCreate))
+		// OnBeforeApplyJoinGroup 注册申请入群之前回调
+		OnBeforeApplyJoinGroup(handler func(ctx context.Context, ack Ack, data *BeforeApplyJoinGroup))
+		// OnBeforeInviteJoinGroup 注册拉人入群之前回调
+		OnBeforeInviteJoinGroup(handler func(ctx context.Context, ack *BeforeInviteJoinGroupAck, data *BeforeInviteJoinGroup))
+		// OnAfterNewMemberJoinGroup 注册新成员入群之后回调
+		OnAfterNewMemberJoinGroup(handler func(ctx context.Context, ack Ack, data *AfterNewMemberJoinGroup))
+		// OnAfterMemberExitGroup 注册群成员离开之后回调
+		OnAfterMemberExitGroup(handler func(ctx context.Context, ack Ack, data *AfterMemberExitGroup))
+		// OnBeforeGroupMessageSend 注册群内发言之前回调
+		OnBeforeGroupMessageSend(handler func(ctx context.Context, ack *BeforeGroupMessageSendAck, data *BeforeGroupMessageSend))
+		// OnAfterGroupMessageSend 注册群内发言之后回调
+		OnAfterGroupMessageSend(handler func(ctx context.Context, ack Ack, data *AfterGroupMessageSend))
+		// OnAfterGroupFull 注册群组满员之后回调
+		OnAfterGroupFull(handler func(ctx context.Context, ack Ack, data *AfterGroupFull))
+		// OnAfterGroupDestroyed 注册群组解散之后回调
+		OnAfterGroupDestroyed(handler func(ctx context.Context, ack Ack, data *AfterGroupDestroyed))
+		// OnAfterGroupInfoChanged 注册群组资料修改之后回调
+		OnAfterGroupInfoChanged(handler func(ctx context.Context, ack Ack, data *AfterGroupInfoChanged))
+		// OnAfterGroupMessageExtensionChange 注册群消息扩展变更之后回调
+		OnAfterGroupMessageExtensionChange(handler func(ctx context.Context, ack Ack, data *AfterGroupMessageExtensionChange))
+		// OnAfterProfileUpdate 注册资料更新之后回调
+		OnAfterProfileUpdate(handler func(ctx context.Context, ack Ack, data *AfterProfileUpdate))
+		// OnAfterPrivateMessageModify 注册单聊消息修改之后回调
+		OnAfterPrivateMessageModify(handler func(ctx context.Context, ack Ack, data *AfterPrivateMessageModify))
+		// OnAfterGroupMessageRevoke 注册群消息撤回之后回调
+		OnAfterGroupMessageRevoke(handler func(ctx context.Context, ack Ack, data *AfterGroupMessageRevoke))
+		// OnAfterGroupMessageModify 注册群消息修改之后回调
+		OnAfterGroupMessageModify(handler func(ctx context.Context, ack Ack, data *AfterGroupMessageModify))
+		// OnAfterGroupMemberRoleChange 注册群成员角色变更之后回调
+		OnAfterGroupMemberRoleChange(handler func(ctx context.Context, ack Ack, data *AfterGroupMemberRoleChange))
+		// OnAfterGroupAttrChange 注册群属性变更之后回调
+		OnAfterGroupAttrChange(handler func(ctx context.Context, ack Ack, data *AfterGroupAttrChange))
+		// OnBeforeTopicCreate 注册创建话题之前回调
+		OnBeforeTopicCreate(handler func(ctx context.Context, ack Ack, data *BeforeTopicCreate))
+		// OnAfterTopicCreate 注册创建话题之后回调
+		OnAfterTopicCreate(handler func(ctx context.Context, ack Ack, data *AfterTopicCreate))
+		// OnAfterTopicDestroyed 注册话题解散之后回调
+		OnAfterTopicDestroyed(handler func(ctx context.Context, ack Ack, data *AfterTopicDestroyed))
+		// OnAfterTopicInfoChanged 注册话题资料修改之后回调
+		OnAfterTopicInfoChanged(handler func(ctx context.Context, ack Ack, data *AfterTopicInfoChanged))
+		// OnBeforeConversationGroupCreate 注册创建会话分组之前回调
+		OnBeforeConversationGroupCreate(handler func(ctx context.Context, ack Ack, data *BeforeConversationGroupCreate))
+		// OnAfterConversationGroupCreate 注册创建会话分组之后回调
+		OnAfterConversationGroupCreate(handler func(ctx context.Context, ack Ack, data *AfterConversationGroupCreate))
+		// OnBeforeConversationGroupUpdate 注册更新会话分组之前回调
+		OnBeforeConversationGroupUpdate(handler func(ctx context.Context, ack Ack, data *BeforeConversationGroupUpdate))
+		// OnAfterConversationGroupUpdate 注册更新会话分组之后回调
+		OnAfterConversationGroupUpdate(handler func(ctx context.Context, ack Ack, data *AfterConversationGroupUpdate))
+		// OnBeforeConversationGroupDelete 注册删除会话分组之前回调
+		OnBeforeConversationGroupDelete(handler func(ctx context.Context, ack Ack, data *BeforeConversationGroupDelete))
+		// OnAfterConversationGroupDelete 注册删除会话分组之后回调
+		OnAfterConversationGroupDelete(handler func(ctx context.Context, ack Ack, data *AfterConversationGroupDelete))
+		// OnAfterPrivateMessageExtensionChange 注册单聊消息扩展变更之后回调
+		OnAfterPrivateMessageExtensionChange(handler func(ctx context.Context, ack Ack, data *AfterPrivateMessageExtensionChange))
+		// OnUnknown 注册未知回调命令的处理器
+		OnUnknown(handler func(ctx context.Context, ack Ack, data *UnknownCommand))
+	}
+
+	// typedAck 专用应答器的公共部分，仅开放失败应答与成功应答
+	typedAck struct {
+		ack Ack
+	}
+
+	// BeforeFriendAddAck 添加好友之前回调应答器
+	BeforeFriendAddAck struct{ typedAck }
+
+	// BeforeFriendResponseAck 添加好友回应之前回调应答器
+	BeforeFriendResponseAck struct{ typedAck }
+
+	// BeforePrivateMessageSendAck 发单聊消息之前回调应答器
+	BeforePrivateMessageSendAck struct{ typedAck }
+
+	// BeforeInviteJoinGroupAck 拉人入群之前回调应答器
+	BeforeInviteJoinGroupAck struct{ typedAck }
+
+	// BeforeGroupMessageSendAck 群内发言之前回调应答器
+	BeforeGroupMessageSendAck struct{ typedAck }
+)
+
+// OnStateChange 注册状态变更回调
+func (c *callback) OnStateChange(handler func(ctx context.Context, ack Ack, data *StateChange)) {
+	c.Register(EventStateChange, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*StateChange))
+	})
+}
+
+// OnBeforeFriendAdd 注册添加好友之前回调
+func (c *callback) OnBeforeFriendAdd(handler func(ctx context.Context, ack *BeforeFriendAddAck, data *BeforeFriendAdd)) {
+	c.Register(EventBeforeFriendAdd, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, &BeforeFriendAddAck{typedAck{ack}}, data.(*BeforeFriendAdd))
+	})
+}
+
+// OnBeforeFriendResponse 注册添加好友回应之前回调
+func (c *callback) OnBeforeFriendResponse(handler func(ctx context.Context, ack *BeforeFriendResponseAck, data *BeforeFriendResponse)) {
+	c.Register(EventBeforeFriendResponse, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, &BeforeFriendResponseAck{typedAck{ack}}, data.(*BeforeFriendResponse))
+	})
+}
+
+// OnAfterFriendAdd 注册添加好友之后回调
+func (c *callback) OnAfterFriendAdd(handler func(ctx context.Context, ack Ack, data *AfterFriendAdd)) {
+	c.Register(EventAfterFriendAdd, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*AfterFriendAdd))
+	})
+}
+
+// OnAfterFriendDelete 注册删除好友之后回调
+func (c *callback) OnAfterFriendDelete(handler func(ctx context.Context, ack Ack, data *AfterFriendDelete)) {
+	c.Register(EventAfterFriendDelete, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*AfterFriendDelete))
+	})
+}
+
+// OnAfterBlacklistAdd 注册添加黑名单之后回调
+func (c *callback) OnAfterBlacklistAdd(handler func(ctx context.Context, ack Ack, data *AfterBlacklistAdd)) {
+	c.Register(EventAfterBlacklistAdd, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*AfterBlacklistAdd))
+	})
+}
+
+// OnAfterBlacklistDelete 注册删除黑名单之后回调
+func (c *callback) OnAfterBlacklistDelete(handler func(ctx context.Context, ack Ack, data *AfterBlacklistDelete)) {
+	c.Register(EventAfterBlacklistDelete, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*AfterBlacklistDelete))
+	})
+}
+
+// OnBeforePrivateMessageSend 注册发单聊消息之前回调
+func (c *callback) OnBeforePrivateMessageSend(handler func(ctx context.Context, ack *BeforePrivateMessageSendAck, data *BeforePrivateMessageSend)) {
+	c.Register(EventBeforePrivateMessageSend, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, &BeforePrivateMessageSendAck{typedAck{ack}}, data.(*BeforePrivateMessageSend))
+	})
+}
+
+// OnAfterPrivateMessageSend 注册发单聊消息之后回调
+func (c *callback) OnAfterPrivateMessageSend(handler func(ctx context.Context, ack Ack, data *AfterPrivateMessageSend)) {
+	c.Register(EventAfterPrivateMessageSend, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*AfterPrivateMessageSend))
+	})
+}
+
+// OnAfterPrivateMessageReport 注册单聊消息已读上报后回调
+func (c *callback) OnAfterPrivateMessageReport(handler func(ctx context.Context, ack Ack, data *AfterPrivateMessageReport)) {
+	c.Register(EventAfterPrivateMessageReport, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*AfterPrivateMessageReport))
+	})
+}
+
+// OnAfterPrivateMessageRevoke 注册单聊消息撤回后回调
+func (c *callback) OnAfterPrivateMessageRevoke(handler func(ctx context.Context, ack Ack, data *AfterPrivateMessageRevoke)) {
+	c.Register(EventAfterPrivateMessageRevoke, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*AfterPrivateMessageRevoke))
+	})
+}
+
+// OnBeforeGroupCreate 注册创建群组之前回调
+func (c *callback) OnBeforeGroupCreate(handler func(ctx context.Context, ack Ack, data *BeforeGroupCreate)) {
+	c.Register(EventBeforeGroupCreate, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*BeforeGroupCreate))
+	})
+}
+
+// OnAfterGroupCreate 注册创建群组之后回调
+func (c *callback) OnAfterGroupCreate(handler func(ctx context.Context, ack Ack, data *AfterGroupCreate)) {
+	c.Register(EventAfterGroupCreate, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*AfterGroupCreate))
+	})
+}
+
+// OnBeforeApplyJoinGroup 注册申请入群之前回调
+func (c *callback) OnBeforeApplyJoinGroup(handler func(ctx context.Context, ack Ack, data *BeforeApplyJoinGroup)) {
+	c.Register(EventBeforeApplyJoinGroup, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*BeforeApplyJoinGroup))
+	})
+}
+
+// OnBeforeInviteJoinGroup 注册拉人入群之前回调
+func (c *callback) OnBeforeInviteJoinGroup(handler func(ctx context.Context, ack *BeforeInviteJoinGroupAck, data *BeforeInviteJoinGroup)) {
+	c.Register(EventBeforeInviteJoinGroup, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, &BeforeInviteJoinGroupAck{typedAck{ack}}, data.(*BeforeInviteJoinGroup))
+	})
+}
+
+// OnAfterNewMemberJoinGroup 注册新成员入群之后回调
+func (c *callback) OnAfterNewMemberJoinGroup(handler func(ctx context.Context, ack Ack, data *AfterNewMemberJoinGroup)) {
+	c.Register(EventAfterNewMemberJoinGroup, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*AfterNewMemberJoinGroup))
+	})
+}
+
+// OnAfterMemberExitGroup 注册群成员离开之后回调
+func (c *callback) OnAfterMemberExitGroup(handler func(ctx context.Context, ack Ack, data *AfterMemberExitGroup)) {
+	c.Register(EventAfterMemberExitGroup, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*AfterMemberExitGroup))
+	})
+}
+
+// OnBeforeGroupMessageSend 注册群内发言之前回调
+func (c *callback) OnBeforeGroupMessageSend(handler func(ctx context.Context, ack *BeforeGroupMessageSendAck, data *BeforeGroupMessageSend)) {
+	c.Register(EventBeforeGroupMessageSend, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, &BeforeGroupMessageSendAck{typedAck{ack}}, data.(*BeforeGroupMessageSend))
+	})
+}
+
+// OnAfterGroupMessageSend 注册群内发言之后回调
+func (c *callback) OnAfterGroupMessageSend(handler func(ctx context.Context, ack Ack, data *AfterGroupMessageSend)) {
+	c.Register(EventAfterGroupMessageSend, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*AfterGroupMessageSend))
+	})
+}
+
+// OnAfterGroupFull 注册群组满员之后回调
+func (c *callback) OnAfterGroupFull(handler func(ctx context.Context, ack Ack, data *AfterGroupFull)) {
+	c.Register(EventAfterGroupFull, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*AfterGroupFull))
+	})
+}
+
+// OnAfterGroupDestroyed 注册群组解散之后回调
+func (c *callback) OnAfterGroupDestroyed(handler func(ctx context.Context, ack Ack, data *AfterGroupDestroyed)) {
+	c.Register(EventAfterGroupDestroyed, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*AfterGroupDestroyed))
+	})
+}
+
+// OnAfterGroupInfoChanged 注册群组资料修改之后回调
+func (c *callback) OnAfterGroupInfoChanged(handler func(ctx context.Context, ack Ack, data *AfterGroupInfoChanged)) {
+	c.Register(EventAfterGroupInfoChanged, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*AfterGroupInfoChanged))
+	})
+}
+
+// OnAfterGroupMessageExtensionChange 注册群消息扩展变更之后回调
+func (c *callback) OnAfterGroupMessageExtensionChange(handler func(ctx context.Context, ack Ack, data *AfterGroupMessageExtensionChange)) {
+	c.Register(EventAfterGroupMessageExtensionChange, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*AfterGroupMessageExtensionChange))
+	})
+}
+
+// OnAfterProfileUpdate 注册资料更新之后回调
+func (c *callback) OnAfterProfileUpdate(handler func(ctx context.Context, ack Ack, data *AfterProfileUpdate)) {
+	c.Register(EventAfterProfileUpdate, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*AfterProfileUpdate))
+	})
+}
+
+// OnAfterPrivateMessageModify 注册单聊消息修改之后回调
+func (c *callback) OnAfterPrivateMessageModify(handler func(ctx context.Context, ack Ack, data *AfterPrivateMessageModify)) {
+	c.Register(EventAfterPrivateMessageModify, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*AfterPrivateMessageModify))
+	})
+}
+
+// OnAfterGroupMessageRevoke 注册群消息撤回之后回调
+func (c *callback) OnAfterGroupMessageRevoke(handler func(ctx context.Context, ack Ack, data *AfterGroupMessageRevoke)) {
+	c.Register(EventAfterGroupMessageRevoke, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*AfterGroupMessageRevoke))
+	})
+}
+
+// OnAfterGroupMessageModify 注册群消息修改之后回调
+func (c *callback) OnAfterGroupMessageModify(handler func(ctx context.Context, ack Ack, data *AfterGroupMessageModify)) {
+	c.Register(EventAfterGroupMessageModify, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*AfterGroupMessageModify))
+	})
+}
+
+// OnAfterGroupMemberRoleChange 注册群成员角色变更之后回调
+func (c *callback) OnAfterGroupMemberRoleChange(handler func(ctx context.Context, ack Ack, data *AfterGroupMemberRoleChange)) {
+	c.Register(EventAfterGroupMemberRoleChange, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*AfterGroupMemberRoleChange))
+	})
+}
+
+// OnAfterGroupAttrChange 注册群属性变更之后回调
+func (c *callback) OnAfterGroupAttrChange(handler func(ctx context.Context, ack Ack, data *AfterGroupAttrChange)) {
+	c.Register(EventAfterGroupAttrChange, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*AfterGroupAttrChange))
+	})
+}
+
+// OnBeforeTopicCreate 注册创建话题之前回调
+func (c *callback) OnBeforeTopicCreate(handler func(ctx context.Context, ack Ack, data *BeforeTopicCreate)) {
+	c.Register(EventBeforeTopicCreate, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*BeforeTopicCreate))
+	})
+}
+
+// OnAfterTopicCreate 注册创建话题之后回调
+func (c *callback) OnAfterTopicCreate(handler func(ctx context.Context, ack Ack, data *AfterTopicCreate)) {
+	c.Register(EventAfterTopicCreate, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*AfterTopicCreate))
+	})
+}
+
+// OnAfterTopicDestroyed 注册话题解散之后回调
+func (c *callback) OnAfterTopicDestroyed(handler func(ctx context.Context, ack Ack, data *AfterTopicDestroyed)) {
+	c.Register(EventAfterTopicDestroyed, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*AfterTopicDestroyed))
+	})
+}
+
+// OnAfterTopicInfoChanged 注册话题资料修改之后回调
+func (c *callback) OnAfterTopicInfoChanged(handler func(ctx context.Context, ack Ack, data *AfterTopicInfoChanged)) {
+	c.Register(EventAfterTopicInfoChanged, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*AfterTopicInfoChanged))
+	})
+}
+
+// OnBeforeConversationGroupCreate 注册创建会话分组之前回调
+func (c *callback) OnBeforeConversationGroupCreate(handler func(ctx context.Context, ack Ack, data *BeforeConversationGroupCreate)) {
+	c.Register(EventBeforeConversationGroupCreate, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*BeforeConversationGroupCreate))
+	})
+}
+
+// OnAfterConversationGroupCreate 注册创建会话分组之后回调
+func (c *callback) OnAfterConversationGroupCreate(handler func(ctx context.Context, ack Ack, data *AfterConversationGroupCreate)) {
+	c.Register(EventAfterConversationGroupCreate, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*AfterConversationGroupCreate))
+	})
+}
+
+// OnBeforeConversationGroupUpdate 注册更新会话分组之前回调
+func (c *callback) OnBeforeConversationGroupUpdate(handler func(ctx context.Context, ack Ack, data *BeforeConversationGroupUpdate)) {
+	c.Register(EventBeforeConversationGroupUpdate, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*BeforeConversationGroupUpdate))
+	})
+}
+
+// OnAfterConversationGroupUpdate 注册更新会话分组之后回调
+func (c *callback) OnAfterConversationGroupUpdate(handler func(ctx context.Context, ack Ack, data *AfterConversationGroupUpdate)) {
+	c.Register(EventAfterConversationGroupUpdate, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*AfterConversationGroupUpdate))
+	})
+}
+
+// OnBeforeConversationGroupDelete 注册删除会话分组之前回调
+func (c *callback) OnBeforeConversationGroupDelete(handler func(ctx context.Context, ack Ack, data *BeforeConversationGroupDelete)) {
+	c.Register(EventBeforeConversationGroupDelete, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*BeforeConversationGroupDelete))
+	})
+}
+
+// OnAfterConversationGroupDelete 注册删除会话分组之后回调
+func (c *callback) OnAfterConversationGroupDelete(handler func(ctx context.Context, ack Ack, data *AfterConversationGroupDelete)) {
+	c.Register(EventAfterConversationGroupDelete, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*AfterConversationGroupDelete))
+	})
+}
+
+// OnAfterPrivateMessageExtensionChange 注册单聊消息扩展变更之后回调
+func (c *callback) OnAfterPrivateMessageExtensionChange(handler func(ctx context.Context, ack Ack, data *AfterPrivateMessageExtensionChange)) {
+	c.Register(EventAfterPrivateMessageExtensionChange, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*AfterPrivateMessageExtensionChange))
+	})
+}
+
+// OnUnknown 注册未知回调命令的处理器
+func (c *callback) OnUnknown(handler func(ctx context.Context, ack Ack, data *UnknownCommand)) {
+	c.Register(EventUnknown, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ctx, ack, data.(*UnknownCommand))
+	})
+}
+
+// AckResp 应答添加好友之前回调的处理结果
+func (a *BeforeFriendAddAck) AckResp(resp *BeforeFriendAddResp) error {
+	return a.ack.Ack(fillResp(resp, &resp.BaseResp))
+}
+
+// AckResp 应答添加好友回应之前回调的处理结果
+func (a *BeforeFriendResponseAck) AckResp(resp *BeforeFriendResponseResp) error {
+	return a.ack.Ack(fillResp(resp, &resp.BaseResp))
+}
+
+// AckResp 应答发单聊消息之前回调的处理结果
+func (a *BeforePrivateMessageSendAck) AckResp(resp *BeforePrivateMessageSendResp) error {
+	return a.ack.Ack(fillResp(resp, &resp.BaseResp))
+}
+
+// AckResp 应答拉人入群之前回调的处理结果
+func (a *BeforeInviteJoinGroupAck) AckResp(resp *BeforeInviteJoinGroupResp) error {
+	return a.ack.Ack(fillResp(resp, &resp.BaseResp))
+}
+
+// AckResp 应答群内发言之前回调的处理结果
+func (a *BeforeGroupMessageSendAck) AckResp(resp *BeforeGroupMessageSendResp) error {
+	return a.ack.Ack(fillResp(resp, &resp.BaseResp))
+}
+
+// AckFailure 应答失败
+func (a typedAck) AckFailure(message ...string) error {
+	return a.ack.AckFailure(message...)
+}
+
+// AckSuccess 应答成功
+func (a typedAck) AckSuccess(code int, message ...string) error {
+	return a.ack.AckSuccess(code, message...)
+}
+
+// fillResp 未设置应答状态时填充为 OK
+// 即时通信 IM 将 ActionStatus 为 FAIL 的应答视为回调失败并按默认规则放行，非0错误码需以 OK 应答才能生效，参见 rejectResp
+func fillResp(resp interface{}, base *BaseResp) interface{} {
+	if base.ActionStatus == "" {
+		base.ActionStatus = ackSuccessStatus
+	}
+
+	return resp
+}
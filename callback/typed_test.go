@@ -0,0 +1,99 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/19 15:00
+ * @Desc: 强类型回调注册测试
+ */
+
+package callback
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func serveCommand(t *testing.T, c *callback, command, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/callback?SdkAppid=1400000000&CallbackCommand="+command, strings.NewReader(body))
+	c.Handler().ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status: %d %s", w.Code, w.Body.String())
+	}
+
+	return w
+}
+
+func TestTyped_Dispatch(t *testing.T) {
+	c := newTestCallback()
+
+	var added *AfterFriendAdd
+	c.OnAfterFriendAdd(func(ctx context.Context, ack Ack, data *AfterFriendAdd) {
+		if EventFromContext(ctx) != EventAfterFriendAdd || CommandFromContext(ctx) != commandAfterFriendAdd {
+			t.Errorf("unexpected event in context: %d %s", EventFromContext(ctx), CommandFromContext(ctx))
+		}
+		added = data
+	})
+
+	serveCommand(t, c, commandAfterFriendAdd, `{"CallbackCommand":"Sns.CallbackFriendAdd","PairList":[{"From_Account":"u1","To_Account":"u2"}]}`)
+	if added == nil || len(added.PairList) != 1 || added.PairList[0].FromUserId != "u1" || added.PairList[0].ToUserId != "u2" {
+		t.Fatalf("unexpected data: %+v", added)
+	}
+
+	var unknown *UnknownCommand
+	c.OnUnknown(func(ctx context.Context, ack Ack, data *UnknownCommand) {
+		unknown = data
+	})
+
+	serveCommand(t, c, "Some.NewCallback", `{"Foo":1}`)
+	if unknown == nil || unknown.CallbackCommand != "Some.NewCallback" || string(unknown.Body) != `{"Foo":1}` {
+		t.Fatalf("unexpected unknown command: %+v", unknown)
+	}
+}
+
+func TestTyped_MergeResp(t *testing.T) {
+	c := newTestCallback()
+
+	c.OnBeforeFriendAdd(func(ctx context.Context, ack *BeforeFriendAddAck, data *BeforeFriendAdd) {
+		_ = ack.AckResp(&BeforeFriendAddResp{Results: []*BeforeFriendAddResult{{UserId: "u2"}, {UserId: "u3"}}})
+	})
+	c.OnBeforeFriendAdd(func(ctx context.Context, ack *BeforeFriendAddAck, data *BeforeFriendAdd) {
+		_ = ack.AckResp(&BeforeFriendAddResp{Results: []*BeforeFriendAddResult{{UserId: "u3", ResultCode: 38001, ResultInfo: "rejected"}}})
+	})
+
+	w := serveCommand(t, c, commandBeforeFriendAdd, `{"CallbackCommand":"Sns.CallbackPrevFriendAdd","From_Account":"u1"}`)
+
+	resp := &BeforeFriendAddResp{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatal(err)
+	}
+
+	// 按 UserID 合并处理结果，拒绝优先
+	if isVetoed(resp) || len(resp.Results) != 2 || resp.Results[0].ResultCode != 0 || resp.Results[1].ResultCode != 38001 {
+		t.Fatalf("unexpected merged response: %s", w.Body.String())
+	}
+}
+
+func TestTyped_RejectStatus(t *testing.T) {
+	c := newTestCallback()
+
+	c.OnBeforePrivateMessageSend(func(ctx context.Context, ack *BeforePrivateMessageSendAck, data *BeforePrivateMessageSend) {
+		_ = ack.AckResp(&BeforePrivateMessageSendResp{BaseResp: BaseResp{ErrorCode: 1}})
+	})
+
+	w := serveCommand(t, c, commandBeforePrivateMessageSend, `{"CallbackCommand":"C2C.CallbackBeforeSendMsg","From_Account":"u1","To_Account":"u2"}`)
+
+	resp := &BeforePrivateMessageSendResp{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatal(err)
+	}
+
+	// 未设置应答状态的拒绝应答需以 OK 返回，否则即时通信 IM 视为回调失败并放行
+	if resp.ActionStatus != ackSuccessStatus || resp.ErrorCode != 1 {
+		t.Fatalf("unexpected response: %s", w.Body.String())
+	}
+}
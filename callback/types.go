@@ -7,7 +7,11 @@
 
 package callback
 
-import "github.com/default-yarns/tencent-im/internal/types"
+import (
+	"encoding/json"
+
+	"github.com/default-yarns/tencent-im/internal/types"
+)
 
 const (
 	TIMTextElem      = "TIMTextElem"      // 文本消息
@@ -89,7 +93,7 @@ type (
 	// BeforeFriendResponseResp 添加好友之前回调应答
 	BeforeFriendResponseResp struct {
 		BaseResp
-		Results []*BeforeFriendAddResult `json:"ResultItem"` // App 后台的处理结果
+		Results []*BeforeFriendResponseResult `json:"ResultItem"` // App 后台的处理结果
 	}
 
 	// BeforeFriendResponseResult App后台的处理结果
@@ -99,7 +103,7 @@ type (
 		ResultInfo string `json:"ResultInfo"` // （必填）错误信息
 	}
 
-	// AfterFriendAdd 添加好友之后
+	// AfterFriendAdd 添加好友之后回调
 	AfterFriendAdd struct {
 		CallbackCommand string `json:"CallbackCommand"` // 回调命令
 		ClientCmd       string `json:"ClientCmd"`       // 触发回调的命令字：加好友请求，合理的取值如下：friend_add、FriendAdd; 加好友回应，合理的取值如下：friend_response、FriendResponse
@@ -341,4 +345,239 @@ type (
 		Notification    string `json:"Notification"`     // 修改后的群公告
 		OperatorUserId  string `json:"Operator_Account"` // 请求的发起者
 	}
+
+	// AfterGroupMessageExtensionChange 群消息扩展变更之后回调
+	AfterGroupMessageExtensionChange struct {
+		CallbackCommand string          `json:"CallbackCommand"` // 回调命令
+		GroupId         string          `json:"GroupId"`         // 群ID
+		Type            string          `json:"Type"`            // 群组类型
+		MsgSeq          int             `json:"MsgSeq"`          // 消息的序列号
+		OperateType     int             `json:"OperateType"`     // 操作类型：1表示设置；2表示删除；3表示清空
+		ExtensionList   []*MsgExtension `json:"ExtensionList"`   // 变更的扩展项列表
+		EventTime       int64           `json:"EventTime"`       // 事件触发的毫秒级别时间戳
+	}
+
+	// MsgExtension 消息扩展项
+	MsgExtension = types.MsgExtension
+
+	// AfterPrivateMessageExtensionChange 单聊消息扩展变更之后回调
+	AfterPrivateMessageExtensionChange struct {
+		CallbackCommand string          `json:"CallbackCommand"`  // 回调命令
+		FromUserId      string          `json:"From_Account"`     // 消息发送者 UserID
+		ToUserId        string          `json:"To_Account"`       // 消息接收者 UserID
+		OperatorUserId  string          `json:"Operator_Account"` // 操作者 UserID
+		MsgKey          string          `json:"MsgKey"`           // 该条消息的唯一标识
+		OperateType     int             `json:"OperateType"`      // 操作类型：1表示设置；2表示删除；3表示清空
+		ExtensionList   []*MsgExtension `json:"ExtensionList"`    // 变更的扩展项列表
+		EventTime       int64           `json:"EventTime"`        // 事件触发的毫秒级别时间戳
+	}
+
+	// AfterProfileUpdate 资料更新之后回调，用户资料变更后通知 App 后台，可用于同步好友资料
+	AfterProfileUpdate struct {
+		CallbackCommand string         `json:"CallbackCommand"`  // 回调命令
+		EventTime       int64          `json:"EventTime"`        // 触发本次回调的时间戳，单位为毫秒
+		OperatorUserId  string         `json:"Operator_Account"` // 请求的发起者
+		UserId          string         `json:"From_Account"`     // 资料被修改的用户 UserID
+		ProfileList     []*ProfileItem `json:"ProfileItem"`      // 变更的资料字段列表
+	}
+
+	// ProfileItem 资料字段
+	ProfileItem struct {
+		Tag   string      `json:"Tag"`   // 资料字段的名称
+		Value interface{} `json:"Value"` // 资料字段的值
+	}
+
+	// AfterPrivateMessageModify 单聊消息修改之后回调
+	AfterPrivateMessageModify struct {
+		CallbackCommand string     `json:"CallbackCommand"` // 回调命令
+		FromUserId      string     `json:"From_Account"`    // 消息发送者 UserID
+		ToUserId        string     `json:"To_Account"`      // 消息接收者 UserID
+		MsgSeq          uint32     `json:"MsgSeq"`          // 消息序列号
+		MsgRandom       uint32     `json:"MsgRandom"`       // 消息随机数
+		MsgTime         int64      `json:"MsgTime"`         // 消息的发送时间戳，单位为秒
+		MsgKey          string     `json:"MsgKey"`          // 该条消息的唯一标识
+		OnlineOnlyFlag  int        `json:"OnlineOnlyFlag"`  // 在线消息，为1，否则为0
+		MsgBody         []*MsgBody `json:"MsgBody"`         // 修改后的消息体
+		CloudCustomData string     `json:"CloudCustomData"` // 修改后的消息自定义数据
+		EventTime       int64      `json:"EventTime"`       // 事件触发的毫秒级别时间戳
+	}
+
+	// AfterGroupMessageRevoke 群消息撤回之后回调
+	AfterGroupMessageRevoke struct {
+		CallbackCommand string `json:"CallbackCommand"`  // 回调命令
+		GroupId         string `json:"GroupId"`          // 群ID
+		Type            string `json:"Type"`             // 群组类型
+		OperatorUserId  string `json:"Operator_Account"` // 请求的发起者
+		TopicId         string `json:"TopicId"`          // 话题ID，仅在话题中撤回消息时有效
+		MsgSeqList      []struct {
+			MsgSeq int `json:"MsgSeq"` // 被撤回的消息的序列号
+		} `json:"MsgSeqList"` // 撤回的消息序列号列表
+		EventTime int64 `json:"EventTime"` // 事件触发的毫秒级别时间戳
+	}
+
+	// AfterGroupMessageModify 群消息修改之后回调
+	AfterGroupMessageModify struct {
+		CallbackCommand string           `json:"CallbackCommand"`  // 回调命令
+		GroupId         string           `json:"GroupId"`          // 群ID
+		Type            string           `json:"Type"`             // 群组类型
+		FromUserId      string           `json:"From_Account"`     // 消息发送者
+		OperatorUserId  string           `json:"Operator_Account"` // 请求的发起者
+		TopicId         string           `json:"TopicId"`          // 话题ID，仅在话题中修改消息时有效
+		MsgSeq          int              `json:"MsgSeq"`           // 消息的序列号
+		MsgRandom       int              `json:"Random"`           // 随机数
+		MsgTime         int64            `json:"MsgTime"`          // 消息的时间
+		MsgBody         []*types.MsgBody `json:"MsgBody"`          // 修改后的消息体
+		CloudCustomData string           `json:"CloudCustomData"`  // 修改后的消息自定义数据
+		EventTime       int64            `json:"EventTime"`        // 事件触发的毫秒级别时间戳
+	}
+
+	// AfterGroupMemberRoleChange 群成员角色变更之后回调
+	AfterGroupMemberRoleChange struct {
+		CallbackCommand string `json:"CallbackCommand"`  // 回调命令
+		GroupId         string `json:"GroupId"`          // 群ID
+		Type            string `json:"Type"`             // 群组类型
+		OperatorUserId  string `json:"Operator_Account"` // 请求的发起者
+		MemberList      []struct {
+			UserId string `json:"Member_Account"` // 成员 UserID
+			Role   string `json:"Role"`           // 变更后的角色：Admin（管理员）；Member（普通成员）
+		} `json:"MemberList"` // 角色变更的成员列表
+		EventTime int64 `json:"EventTime"` // 事件触发的毫秒级别时间戳
+	}
+
+	// AfterGroupAttrChange 群属性变更之后回调
+	AfterGroupAttrChange struct {
+		CallbackCommand string       `json:"CallbackCommand"`  // 回调命令
+		GroupId         string       `json:"GroupId"`          // 群ID
+		Type            string       `json:"Type"`             // 群组类型
+		OperatorUserId  string       `json:"Operator_Account"` // 请求的发起者
+		OperateType     int          `json:"OperateType"`      // 操作类型：1表示设置；2表示删除；3表示清空；4表示重置
+		AttrList        []*GroupAttr `json:"GroupAttr"`        // 变更的群属性列表
+		EventTime       int64        `json:"EventTime"`        // 事件触发的毫秒级别时间戳
+	}
+
+	// GroupAttr 群属性
+	GroupAttr struct {
+		Key   string `json:"key"`   // 群属性的键
+		Value string `json:"value"` // 群属性的值
+	}
+
+	// BeforeTopicCreate 创建话题之前回调
+	BeforeTopicCreate struct {
+		CallbackCommand string `json:"CallbackCommand"`  // 回调命令
+		GroupId         string `json:"GroupId"`          // 话题所属的社群ID
+		Type            string `json:"Type"`             // 群组类型
+		OperatorUserId  string `json:"Operator_Account"` // 操作者
+		OwnerUserId     string `json:"Owner_Account"`    // 话题的所有者
+		TopicName       string `json:"TopicName"`        // 请求创建的话题名称
+		EventTime       int64  `json:"EventTime"`        // 事件触发的毫秒级别时间戳
+	}
+
+	// AfterTopicCreate 创建话题之后回调
+	AfterTopicCreate struct {
+		CallbackCommand string `json:"CallbackCommand"`  // 回调命令
+		GroupId         string `json:"GroupId"`          // 话题所属的社群ID
+		Type            string `json:"Type"`             // 群组类型
+		TopicId         string `json:"TopicId"`          // 话题ID
+		TopicName       string `json:"TopicName"`        // 话题名称
+		OperatorUserId  string `json:"Operator_Account"` // 操作者
+		OwnerUserId     string `json:"Owner_Account"`    // 话题的所有者
+		EventTime       int64  `json:"EventTime"`        // 事件触发的毫秒级别时间戳
+	}
+
+	// AfterTopicDestroyed 话题解散之后回调
+	AfterTopicDestroyed struct {
+		CallbackCommand string `json:"CallbackCommand"`  // 回调命令
+		GroupId         string `json:"GroupId"`          // 话题所属的社群ID
+		Type            string `json:"Type"`             // 群组类型
+		OperatorUserId  string `json:"Operator_Account"` // 操作者
+		TopicIdList     []struct {
+			TopicId string `json:"TopicId"` // 话题ID
+		} `json:"TopicIdList"` // 被解散的话题列表
+		EventTime int64 `json:"EventTime"` // 事件触发的毫秒级别时间戳
+	}
+
+	// AfterTopicInfoChanged 话题资料修改之后回调
+	AfterTopicInfoChanged struct {
+		CallbackCommand string `json:"CallbackCommand"`  // 回调命令
+		GroupId         string `json:"GroupId"`          // 话题所属的社群ID
+		Type            string `json:"Type"`             // 群组类型
+		TopicId         string `json:"TopicId"`          // 话题ID
+		OperatorUserId  string `json:"Operator_Account"` // 操作者
+		TopicName       string `json:"TopicName"`        // 修改后的话题名称
+		Introduction    string `json:"Introduction"`     // 修改后的话题简介
+		Notification    string `json:"Notification"`     // 修改后的话题公告
+		FaceUrl         string `json:"FaceUrl"`          // 修改后的话题头像
+		EventTime       int64  `json:"EventTime"`        // 事件触发的毫秒级别时间戳
+	}
+
+	// BeforeConversationGroupCreate 创建会话分组之前回调
+	BeforeConversationGroupCreate struct {
+		CallbackCommand string                 `json:"CallbackCommand"` // 回调命令
+		UserId          string                 `json:"From_Account"`    // 会话分组所属的用户 UserID
+		GroupName       string                 `json:"GroupName"`       // 会话分组名称
+		ContactList     []*ConversationContact `json:"ContactItem"`     // 加入分组的会话列表
+		EventTime       int64                  `json:"EventTime"`       // 事件触发的毫秒级别时间戳
+	}
+
+	// AfterConversationGroupCreate 创建会话分组之后回调
+	AfterConversationGroupCreate struct {
+		CallbackCommand string                 `json:"CallbackCommand"` // 回调命令
+		UserId          string                 `json:"From_Account"`    // 会话分组所属的用户 UserID
+		GroupName       string                 `json:"GroupName"`       // 会话分组名称
+		ContactList     []*ConversationContact `json:"ContactItem"`     // 加入分组的会话列表
+		EventTime       int64                  `json:"EventTime"`       // 事件触发的毫秒级别时间戳
+	}
+
+	// BeforeConversationGroupUpdate 更新会话分组之前回调
+	BeforeConversationGroupUpdate struct {
+		CallbackCommand   string                 `json:"CallbackCommand"`   // 回调命令
+		UserId            string                 `json:"From_Account"`      // 会话分组所属的用户 UserID
+		GroupName         string                 `json:"GroupName"`         // 会话分组名称
+		UpdateType        int                    `json:"UpdateType"`        // 更新类型：1表示修改分组名；2表示更新分组中的会话
+		NewGroupName      string                 `json:"NewGroupName"`      // 修改后的分组名，仅 UpdateType 为1时有效
+		ContactUpdateType int                    `json:"ContactUpdateType"` // 会话更新类型：1表示加入会话；2表示移除会话，仅 UpdateType 为2时有效
+		ContactList       []*ConversationContact `json:"ContactItem"`       // 加入或移除的会话列表
+		EventTime         int64                  `json:"EventTime"`         // 事件触发的毫秒级别时间戳
+	}
+
+	// AfterConversationGroupUpdate 更新会话分组之后回调
+	AfterConversationGroupUpdate struct {
+		CallbackCommand   string                 `json:"CallbackCommand"`   // 回调命令
+		UserId            string                 `json:"From_Account"`      // 会话分组所属的用户 UserID
+		GroupName         string                 `json:"GroupName"`         // 会话分组名称
+		UpdateType        int                    `json:"UpdateType"`        // 更新类型：1表示修改分组名；2表示更新分组中的会话
+		NewGroupName      string                 `json:"NewGroupName"`      // 修改后的分组名，仅 UpdateType 为1时有效
+		ContactUpdateType int                    `json:"ContactUpdateType"` // 会话更新类型：1表示加入会话；2表示移除会话，仅 UpdateType 为2时有效
+		ContactList       []*ConversationContact `json:"ContactItem"`       // 加入或移除的会话列表
+		EventTime         int64                  `json:"EventTime"`         // 事件触发的毫秒级别时间戳
+	}
+
+	// BeforeConversationGroupDelete 删除会话分组之前回调
+	BeforeConversationGroupDelete struct {
+		CallbackCommand string `json:"CallbackCommand"` // 回调命令
+		UserId          string `json:"From_Account"`    // 会话分组所属的用户 UserID
+		GroupName       string `json:"GroupName"`       // 会话分组名称
+		EventTime       int64  `json:"EventTime"`       // 事件触发的毫秒级别时间戳
+	}
+
+	// AfterConversationGroupDelete 删除会话分组之后回调
+	AfterConversationGroupDelete struct {
+		CallbackCommand string `json:"CallbackCommand"` // 回调命令
+		UserId          string `json:"From_Account"`    // 会话分组所属的用户 UserID
+		GroupName       string `json:"GroupName"`       // 会话分组名称
+		EventTime       int64  `json:"EventTime"`       // 事件触发的毫秒级别时间戳
+	}
+
+	// ConversationContact 会话分组中的会话
+	ConversationContact struct {
+		Type     int    `json:"Type"`                 // 会话类型：1表示C2C会话；2表示G2C会话
+		ToUserId string `json:"To_Account,omitempty"` // C2C会话的对端 UserID
+		GroupId  string `json:"ToGroupId,omitempty"`  // G2C会话的群ID
+	}
+
+	// UnknownCommand SDK 尚未支持的回调命令，原样透传回调命令与请求体
+	UnknownCommand struct {
+		CallbackCommand string          // 回调命令
+		Body            json.RawMessage // 原始请求体
+	}
 )
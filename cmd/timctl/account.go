@@ -0,0 +1,107 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 17:10
+ * @Desc: 账号管理命令
+ */
+
+package main
+
+import (
+	"flag"
+
+	"github.com/default-yarns/tencent-im"
+	"github.com/default-yarns/tencent-im/account"
+)
+
+var accountModule = &module{
+	name: "account",
+	desc: "账号管理",
+	commands: []*command{
+		{
+			name:  "import",
+			desc:  "导入单个账号",
+			usage: "[-nick 昵称] [-face 头像URL] <userId>",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				nick := fs.String("nick", "", "用户昵称")
+				face := fs.String("face", "", "用户头像URL")
+				args, err := parseArgs(fs, args, 1, "userId")
+				if err != nil {
+					return nil, err
+				}
+
+				return nil, tim.Account().ImportAccount(&account.Account{UserId: args[0], Nickname: *nick, FaceUrl: *face})
+			},
+		},
+		{
+			name:  "delete",
+			desc:  "删除账号",
+			usage: "<userId>...",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				args, err := parseArgs(fs, args, 1, "userId")
+				if err != nil {
+					return nil, err
+				}
+
+				return tim.Account().DeleteAccounts(args...)
+			},
+		},
+		{
+			name:  "check",
+			desc:  "查询账号导入状态",
+			usage: "<userId>...",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				args, err := parseArgs(fs, args, 1, "userId")
+				if err != nil {
+					return nil, err
+				}
+
+				return tim.Account().CheckAccounts(args...)
+			},
+		},
+		{
+			name:  "kick",
+			desc:  "使账号登录态失效",
+			usage: "<userId>",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				args, err := parseArgs(fs, args, 1, "userId")
+				if err != nil {
+					return nil, err
+				}
+
+				return nil, tim.Account().KickAccount(args[0])
+			},
+		},
+		{
+			name:  "state",
+			desc:  "查询账号在线状态",
+			usage: "[-detail] <userId>...",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				detail := fs.Bool("detail", false, "是否返回详细的登录平台信息")
+				args, err := parseArgs(fs, args, 1, "userId")
+				if err != nil {
+					return nil, err
+				}
+
+				ret, err := tim.Account().GetAccountsOnlineState(args, *detail)
+				if err != nil {
+					return nil, err
+				}
+
+				return ret.Results, nil
+			},
+		},
+		{
+			name:  "robots",
+			desc:  "获取所有机器人账号",
+			usage: "",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				if _, err := parseArgs(fs, args, 0); err != nil {
+					return nil, err
+				}
+
+				return tim.Account().FetchRobots()
+			},
+		},
+	},
+}
@@ -0,0 +1,100 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 18:30
+ * @Desc: 备份命令
+ */
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"os"
+
+	"github.com/default-yarns/tencent-im"
+	"github.com/default-yarns/tencent-im/backup"
+)
+
+var backupModule = &module{
+	name: "backup",
+	desc: "备份导出",
+	commands: []*command{
+		{
+			name:  "export",
+			desc:  "导出备份文件（JSON Lines），指定状态文件时进行增量备份",
+			usage: "-out 备份文件 [-users userId,...] [-state 状态文件] [-entities group,member,...]",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				out := fs.String("out", "", "备份文件路径")
+				users := fs.String("users", "", "需要备份资料、好友、黑名单和会话的账号，逗号分隔")
+				stateFile := fs.String("state", "", "增量备份状态文件路径")
+				entities := fs.String("entities", "", "需要备份的实体类型，逗号分隔，默认为全部")
+				if _, err := parseArgs(fs, args, 0); err != nil {
+					return nil, err
+				}
+
+				if *out == "" {
+					return nil, errors.New("the backup file is not set")
+				}
+
+				opt := &backup.Options{
+					UserIds:   splitList(*users),
+					StateFile: *stateFile,
+				}
+				for _, entity := range splitList(*entities) {
+					opt.Entities = append(opt.Entities, backup.Entity(entity))
+				}
+
+				file, err := os.Create(*out)
+				if err != nil {
+					return nil, err
+				}
+				defer file.Close()
+
+				summary, err := backup.NewExporter(tim, opt).Export(file)
+				if err != nil {
+					return nil, err
+				}
+
+				return summary.Counts, nil
+			},
+		},
+		{
+			name:  "verify",
+			desc:  "校验备份文件并统计记录数",
+			usage: "<file>",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				args, err := parseArgs(fs, args, 1, "file")
+				if err != nil {
+					return nil, err
+				}
+
+				file, err := os.Open(args[0])
+				if err != nil {
+					return nil, err
+				}
+				defer file.Close()
+
+				reader, err := backup.NewReader(file)
+				if err != nil {
+					return nil, err
+				}
+
+				counts := make(map[backup.Entity]int)
+				for {
+					record, err := reader.Next()
+					if err == io.EOF {
+						break
+					}
+					if err != nil {
+						return nil, err
+					}
+					counts[record.Type]++
+				}
+
+				return counts, nil
+			},
+		},
+	},
+}
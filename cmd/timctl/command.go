@@ -0,0 +1,138 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 17:00
+ * @Desc: 命令注册
+ */
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/default-yarns/tencent-im"
+)
+
+type (
+	// module 命令模块，与 SDK 的接口包一一对应
+	module struct {
+		name     string
+		desc     string
+		commands []*command
+	}
+
+	// command 模块下的命令
+	command struct {
+		name  string
+		desc  string
+		usage string
+		run   func(tim im.IM, fs *flag.FlagSet, args []string) (ret interface{}, err error)
+	}
+)
+
+var modules = []*module{
+	accountModule,
+	profileModule,
+	snsModule,
+	groupModule,
+	privateModule,
+	pushModule,
+	muteModule,
+	operationModule,
+	recentContactModule,
+	backupModule,
+}
+
+func findModule(name string) *module {
+	for _, m := range modules {
+		if m.name == name {
+			return m
+		}
+	}
+
+	return nil
+}
+
+func (m *module) findCommand(name string) *command {
+	for _, c := range m.commands {
+		if c.name == name {
+			return c
+		}
+	}
+
+	return nil
+}
+
+func newFlagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "用法: timctl %s %s\n", name, usage)
+		fs.PrintDefaults()
+	}
+
+	return fs
+}
+
+// parseArgs 解析命令选项，并校验位置参数的最少数量
+func parseArgs(fs *flag.FlagSet, args []string, min int, names ...string) ([]string, error) {
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if fs.NArg() < min {
+		return nil, fmt.Errorf("missing arguments: %s", strings.Join(names, " "))
+	}
+
+	return fs.Args(), nil
+}
+
+// parseInts 将参数转换为整数列表
+func parseInts(args []string) ([]int, error) {
+	values := make([]int, 0, len(args))
+	for _, arg := range args {
+		v, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number: %s", arg)
+		}
+		values = append(values, v)
+	}
+
+	return values, nil
+}
+
+// splitList 拆分逗号分隔的列表
+func splitList(s string) (items []string) {
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return
+}
+
+// keyValues 可重复设置的 key=value 选项
+type keyValues map[string]string
+
+func (kv keyValues) String() string {
+	pairs := make([]string, 0, len(kv))
+	for k, v := range kv {
+		pairs = append(pairs, k+"="+v)
+	}
+
+	return strings.Join(pairs, ",")
+}
+
+func (kv keyValues) Set(s string) error {
+	parts := strings.SplitN(s, "=", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return fmt.Errorf("invalid key=value: %s", s)
+	}
+	kv[parts[0]] = parts[1]
+
+	return nil
+}
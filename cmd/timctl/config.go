@@ -0,0 +1,106 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 17:00
+ * @Desc: 命令行配置
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+const (
+	envConfig    = "TIMCTL_CONFIG"
+	envOutput    = "TIMCTL_OUTPUT"
+	envAppId     = "TIM_APP_ID"
+	envAppSecret = "TIM_APP_SECRET"
+	envUserId    = "TIM_USER_ID"
+
+	defaultConfigFile = ".timctl.json"
+	defaultUserId     = "administrator"
+	defaultExpiration = 3600
+)
+
+var errNotSetApp = errors.New("the app id or app secret is not set, please set TIM_APP_ID and TIM_APP_SECRET or use a config file")
+
+// config 命令行配置，环境变量的优先级高于配置文件
+type config struct {
+	AppId      int    `json:"appId"`      // 应用 SDKAppID
+	AppSecret  string `json:"appSecret"`  // 应用密钥
+	UserId     string `json:"userId"`     // 管理员账号
+	Expiration int    `json:"expiration"` // UserSig 过期时间
+	Output     string `json:"output"`     // 输出格式
+}
+
+// loadConfig 加载配置
+func loadConfig(path string) (cfg *config, err error) {
+	cfg = &config{}
+
+	if path == "" {
+		path = os.Getenv(envConfig)
+	}
+
+	if path == "" {
+		if home, e := os.UserHomeDir(); e == nil {
+			if file := filepath.Join(home, defaultConfigFile); fileExists(file) {
+				path = file
+			}
+		}
+	}
+
+	if path != "" {
+		var data []byte
+		if data, err = ioutil.ReadFile(path); err != nil {
+			return
+		}
+
+		if err = json.Unmarshal(data, cfg); err != nil {
+			return
+		}
+	}
+
+	if v := os.Getenv(envAppId); v != "" {
+		if cfg.AppId, err = strconv.Atoi(v); err != nil {
+			return
+		}
+	}
+
+	if v := os.Getenv(envAppSecret); v != "" {
+		cfg.AppSecret = v
+	}
+
+	if v := os.Getenv(envUserId); v != "" {
+		cfg.UserId = v
+	}
+
+	if v := os.Getenv(envOutput); v != "" {
+		cfg.Output = v
+	}
+
+	if cfg.AppId == 0 || cfg.AppSecret == "" {
+		err = errNotSetApp
+		return
+	}
+
+	if cfg.UserId == "" {
+		cfg.UserId = defaultUserId
+	}
+
+	if cfg.Expiration == 0 {
+		cfg.Expiration = defaultExpiration
+	}
+
+	return
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,241 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 17:20
+ * @Desc: 群组管理命令
+ */
+
+package main
+
+import (
+	"flag"
+
+	"github.com/default-yarns/tencent-im"
+	"github.com/default-yarns/tencent-im/group"
+	"github.com/default-yarns/tencent-im/internal/types"
+)
+
+type (
+	// groupView 群组输出
+	groupView struct {
+		GroupId      string `json:"groupId"`
+		Name         string `json:"name"`
+		Type         string `json:"type"`
+		Owner        string `json:"owner"`
+		MemberNum    uint   `json:"memberNum"`
+		MaxMemberNum uint   `json:"maxMemberNum"`
+		CreateTime   int64  `json:"createTime"`
+		LastInfoTime int64  `json:"lastInfoTime"`
+		LastMsgTime  int64  `json:"lastMsgTime"`
+	}
+
+	// memberView 群成员输出
+	memberView struct {
+		UserId      string `json:"userId"`
+		Role        string `json:"role"`
+		NameCard    string `json:"nameCard"`
+		JoinTime    int64  `json:"joinTime"`
+		ShutUpUntil int64  `json:"shutUpUntil"`
+	}
+
+	// groupMessageView 群消息输出
+	groupMessageView struct {
+		MsgSeq  int              `json:"msgSeq"`
+		Sender  string           `json:"sender"`
+		MsgTime int64            `json:"msgTime"`
+		Status  int              `json:"status"`
+		MsgBody []*types.MsgBody `json:"msgBody"`
+	}
+)
+
+var groupModule = &module{
+	name: "group",
+	desc: "群组管理",
+	commands: []*command{
+		{
+			name:  "list",
+			desc:  "拉取 App 中的所有群组",
+			usage: "[-type Public|Private|ChatRoom|AVChatRoom]",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				groupType := fs.String("type", "", "群组类型")
+				if _, err := parseArgs(fs, args, 0); err != nil {
+					return nil, err
+				}
+
+				views := make([]*groupView, 0)
+				err := tim.Group().PullGroups(&group.PullGroupsArg{Limit: 50, Type: group.Type(*groupType)}, func(ret *group.FetchGroupsRet) {
+					for _, g := range ret.List {
+						views = append(views, newGroupView(g))
+					}
+				})
+
+				return views, err
+			},
+		},
+		{
+			name:  "get",
+			desc:  "获取群详细资料",
+			usage: "<groupId>...",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				args, err := parseArgs(fs, args, 1, "groupId")
+				if err != nil {
+					return nil, err
+				}
+
+				groups, err := tim.Group().GetGroups(args)
+				if err != nil {
+					return nil, err
+				}
+
+				views := make([]*groupView, 0, len(groups))
+				for _, g := range groups {
+					views = append(views, newGroupView(g))
+				}
+
+				return views, nil
+			},
+		},
+		{
+			name:  "members",
+			desc:  "拉取全部群成员",
+			usage: "<groupId>",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				args, err := parseArgs(fs, args, 1, "groupId")
+				if err != nil {
+					return nil, err
+				}
+
+				views := make([]*memberView, 0)
+				err = tim.Group().PullMembers(&group.PullMembersArg{GroupId: args[0], Limit: 500}, func(ret *group.FetchMembersRet) {
+					for _, member := range ret.List {
+						views = append(views, &memberView{
+							UserId:      member.GetUserId(),
+							Role:        member.GetRole(),
+							NameCard:    member.GetNameCard(),
+							JoinTime:    member.GetJoinTime().Unix(),
+							ShutUpUntil: member.GetShutUpUntil(),
+						})
+					}
+				})
+
+				return views, err
+			},
+		},
+		{
+			name:  "destroy",
+			desc:  "解散群组",
+			usage: "<groupId>",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				args, err := parseArgs(fs, args, 1, "groupId")
+				if err != nil {
+					return nil, err
+				}
+
+				return nil, tim.Group().DestroyGroup(args[0])
+			},
+		},
+		{
+			name:  "notify",
+			desc:  "在群组中发送系统通知",
+			usage: "<groupId> <content> [userId...]",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				args, err := parseArgs(fs, args, 2, "groupId", "content")
+				if err != nil {
+					return nil, err
+				}
+
+				return nil, tim.Group().SendNotification(args[0], args[1], args[2:]...)
+			},
+		},
+		{
+			name:  "revoke",
+			desc:  "撤回群消息",
+			usage: "<groupId> <msgSeq>...",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				args, err := parseArgs(fs, args, 2, "groupId", "msgSeq")
+				if err != nil {
+					return nil, err
+				}
+
+				seqs, err := parseInts(args[1:])
+				if err != nil {
+					return nil, err
+				}
+
+				return tim.Group().RevokeMessages(args[0], seqs...)
+			},
+		},
+		{
+			name:  "ban",
+			desc:  "群成员封禁",
+			usage: "-duration 秒数 [-reason 原因] <groupId> <userId>...",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				duration := fs.Int64("duration", 3600, "封禁时长，单位为秒")
+				reason := fs.String("reason", "", "封禁原因")
+				args, err := parseArgs(fs, args, 2, "groupId", "userId")
+				if err != nil {
+					return nil, err
+				}
+
+				return nil, tim.Group().BanMembers(args[0], args[1:], *duration, *reason)
+			},
+		},
+		{
+			name:  "unban",
+			desc:  "群成员解封",
+			usage: "<groupId> <userId>...",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				args, err := parseArgs(fs, args, 2, "groupId", "userId")
+				if err != nil {
+					return nil, err
+				}
+
+				return nil, tim.Group().UnbanMembers(args[0], args[1:])
+			},
+		},
+		{
+			name:  "messages",
+			desc:  "拉取群历史消息",
+			usage: "[-limit 20] <groupId>",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				limit := fs.Int("limit", 20, "拉取的消息条数，最多20条")
+				args, err := parseArgs(fs, args, 1, "groupId")
+				if err != nil {
+					return nil, err
+				}
+
+				ret, err := tim.Group().FetchMessages(args[0], *limit)
+				if err != nil {
+					return nil, err
+				}
+
+				views := make([]*groupMessageView, 0, len(ret.List))
+				for _, message := range ret.List {
+					views = append(views, &groupMessageView{
+						MsgSeq:  message.GetSerialNo(),
+						Sender:  message.GetSender(),
+						MsgTime: message.GetTimestamp(),
+						Status:  int(message.GetStatus()),
+						MsgBody: message.GetBody(),
+					})
+				}
+
+				return views, nil
+			},
+		},
+	},
+}
+
+func newGroupView(g *group.Group) *groupView {
+	return &groupView{
+		GroupId:      g.GetGroupId(),
+		Name:         g.GetName(),
+		Type:         string(g.GetGroupType()),
+		Owner:        g.GetOwner(),
+		MemberNum:    g.GetMemberNum(),
+		MaxMemberNum: g.GetMaxMemberNum(),
+		CreateTime:   g.GetCreateTime(),
+		LastInfoTime: g.GetLastInfoTime().Unix(),
+		LastMsgTime:  g.GetLastMsgTime().Unix(),
+	}
+}
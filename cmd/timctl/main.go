@@ -0,0 +1,143 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 17:00
+ * @Desc: 即时通信 IM 管理命令行工具
+ */
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/default-yarns/tencent-im"
+)
+
+const usageHeader = `timctl 即时通信 IM 管理命令行工具
+
+用法:
+    timctl [全局选项] <模块> <命令> [命令选项] [参数...]
+
+全局选项:
+    -config string  配置文件路径，默认读取环境变量 TIMCTL_CONFIG 或 ~/.timctl.json
+    -output string  输出格式：table 或 json，默认读取环境变量 TIMCTL_OUTPUT，未设置时为 table
+
+环境变量:
+    TIM_APP_ID      应用 SDKAppID
+    TIM_APP_SECRET  应用密钥
+    TIM_USER_ID     管理员账号，默认为 administrator
+
+模块:
+`
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	fs := flag.NewFlagSet("timctl", flag.ContinueOnError)
+	fs.Usage = func() { printUsage() }
+	configFile := fs.String("config", "", "配置文件路径")
+	output := fs.String("output", "", "输出格式：table 或 json")
+
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+
+	args = fs.Args()
+	if len(args) == 0 || args[0] == "help" {
+		printUsage()
+		return 0
+	}
+
+	m := findModule(args[0])
+	if m == nil {
+		fmt.Fprintf(os.Stderr, "unknown module: %s\n\n", args[0])
+		printUsage()
+		return 2
+	}
+
+	if len(args) == 1 || args[1] == "help" {
+		printModuleUsage(m)
+		return 0
+	}
+
+	c := m.findCommand(args[1])
+	if c == nil {
+		fmt.Fprintf(os.Stderr, "unknown command: %s %s\n\n", m.name, args[1])
+		printModuleUsage(m)
+		return 2
+	}
+
+	cfg, err := loadConfig(*configFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "load config failed: %s\n", err)
+		return 1
+	}
+
+	if *output != "" {
+		cfg.Output = *output
+	}
+
+	// 调用接口前校验输出格式，避免执行了有副作用的命令后才报错
+	if err = checkOutput(cfg.Output); err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		return 2
+	}
+
+	tim := im.NewIM(&im.Options{
+		AppId:      cfg.AppId,
+		AppSecret:  cfg.AppSecret,
+		UserId:     cfg.UserId,
+		Expiration: cfg.Expiration,
+	})
+
+	ret, err := c.run(tim, newFlagSet(m.name+" "+c.name, c.usage), args[2:])
+	if err == flag.ErrHelp {
+		return 0
+	}
+
+	if err != nil {
+		if e, ok := err.(im.Error); ok {
+			fmt.Fprintf(os.Stderr, "call %s %s failed, code:%d, message:%s.\n", m.name, c.name, e.Code(), e.Message())
+		} else {
+			fmt.Fprintf(os.Stderr, "call %s %s failed: %s.\n", m.name, c.name, err)
+		}
+		return 1
+	}
+
+	if err = render(os.Stdout, cfg.Output, ret); err != nil {
+		fmt.Fprintf(os.Stderr, "render output failed: %s\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// printUsage 打印工具帮助信息
+func printUsage() {
+	b := &strings.Builder{}
+	b.WriteString(usageHeader)
+	for _, m := range modules {
+		fmt.Fprintf(b, "    %-14s %s\n", m.name, m.desc)
+	}
+	b.WriteString("\n使用 \"timctl <模块> help\" 查看模块下的命令。\n")
+	fmt.Fprint(os.Stderr, b.String())
+}
+
+// printModuleUsage 打印模块帮助信息
+func printModuleUsage(m *module) {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "%s: %s\n\n命令:\n", m.name, m.desc)
+	for _, c := range m.commands {
+		fmt.Fprintf(b, "    %-20s %s\n", c.name, c.desc)
+		fmt.Fprintf(b, "    %-20s timctl %s %s %s\n", "", m.name, c.name, c.usage)
+	}
+	fmt.Fprint(os.Stderr, b.String())
+}
@@ -0,0 +1,64 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 17:30
+ * @Desc: 全局禁言命令
+ */
+
+package main
+
+import (
+	"errors"
+	"flag"
+
+	"github.com/default-yarns/tencent-im"
+)
+
+var muteModule = &module{
+	name: "mute",
+	desc: "全局禁言管理",
+	commands: []*command{
+		{
+			name:  "set",
+			desc:  "设置全局禁言，时长为0表示取消禁言",
+			usage: "[-private 秒数] [-group 秒数] <userId>",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				privateMuteTime := fs.Int("private", -1, "单聊消息禁言时长，单位为秒")
+				groupMuteTime := fs.Int("group", -1, "群组消息禁言时长，单位为秒")
+				args, err := parseArgs(fs, args, 1, "userId")
+				if err != nil {
+					return nil, err
+				}
+
+				var p, g *uint
+				if *privateMuteTime >= 0 {
+					v := uint(*privateMuteTime)
+					p = &v
+				}
+				if *groupMuteTime >= 0 {
+					v := uint(*groupMuteTime)
+					g = &v
+				}
+
+				if p == nil && g == nil {
+					return nil, errors.New("the mute time is not set")
+				}
+
+				return nil, tim.Mute().SetNoSpeaking(args[0], p, g)
+			},
+		},
+		{
+			name:  "get",
+			desc:  "查询全局禁言",
+			usage: "<userId>",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				args, err := parseArgs(fs, args, 1, "userId")
+				if err != nil {
+					return nil, err
+				}
+
+				return tim.Mute().GetNoSpeaking(args[0])
+			},
+		},
+	},
+}
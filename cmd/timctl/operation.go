@@ -0,0 +1,66 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 17:30
+ * @Desc: 运营管理命令
+ */
+
+package main
+
+import (
+	"flag"
+	"time"
+
+	"github.com/default-yarns/tencent-im"
+	"github.com/default-yarns/tencent-im/operation"
+)
+
+var operationModule = &module{
+	name: "operation",
+	desc: "运营管理",
+	commands: []*command{
+		{
+			name:  "data",
+			desc:  "拉取运营数据",
+			usage: "",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				if _, err := parseArgs(fs, args, 0); err != nil {
+					return nil, err
+				}
+
+				return tim.Operation().GetOperationData()
+			},
+		},
+		{
+			name:  "history",
+			desc:  "下载最近消息记录",
+			usage: "[-type C2C|Group] <2006010215>",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				chatType := fs.String("type", string(operation.ChatTypeC2C), "消息类型")
+				args, err := parseArgs(fs, args, 1, "hour")
+				if err != nil {
+					return nil, err
+				}
+
+				msgTime, err := time.ParseInLocation("2006010215", args[0], time.Local)
+				if err != nil {
+					return nil, err
+				}
+
+				return tim.Operation().GetHistoryData(operation.ChatType(*chatType), msgTime)
+			},
+		},
+		{
+			name:  "ips",
+			desc:  "获取服务器 IP 地址",
+			usage: "",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				if _, err := parseArgs(fs, args, 0); err != nil {
+					return nil, err
+				}
+
+				return tim.Operation().GetIPList()
+			},
+		},
+	},
+}
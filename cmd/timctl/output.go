@@ -0,0 +1,156 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 17:00
+ * @Desc: 命令行输出
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// checkOutput 校验输出格式
+func checkOutput(format string) error {
+	switch format {
+	case "", outputTable, outputJSON:
+		return nil
+	default:
+		return fmt.Errorf("invalid output format: %s", format)
+	}
+}
+
+// render 按指定格式输出结果
+func render(w io.Writer, format string, v interface{}) error {
+	switch format {
+	case "", outputTable:
+		return renderTable(w, v)
+	case outputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if v == nil {
+			v = map[string]string{"result": "success"}
+		}
+		return enc.Encode(v)
+	default:
+		return fmt.Errorf("invalid output format: %s", format)
+	}
+}
+
+// renderTable 以表格形式输出结果
+// 结构体切片按字段输出为多列，映射与结构体输出为键值两列，其他值直接输出。
+func renderTable(w io.Writer, v interface{}) error {
+	if v == nil {
+		_, err := fmt.Fprintln(w, "success")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	rv := indirect(reflect.ValueOf(v))
+
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if rv.Len() == 0 {
+			_, err := fmt.Fprintln(w, "(empty)")
+			return err
+		}
+
+		if elem := indirect(rv.Index(0)); elem.Kind() == reflect.Struct {
+			headers := fieldNames(elem.Type())
+			writeRow(tw, headers)
+			for i := 0; i < rv.Len(); i++ {
+				writeRow(tw, fieldValues(indirect(rv.Index(i))))
+			}
+		} else {
+			writeRow(tw, []string{"VALUE"})
+			for i := 0; i < rv.Len(); i++ {
+				writeRow(tw, []string{formatValue(rv.Index(i))})
+			}
+		}
+	case reflect.Map:
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		writeRow(tw, []string{"KEY", "VALUE"})
+		for _, key := range keys {
+			writeRow(tw, []string{fmt.Sprint(key.Interface()), formatValue(rv.MapIndex(key))})
+		}
+	case reflect.Struct:
+		names, values := fieldNames(rv.Type()), fieldValues(rv)
+		writeRow(tw, []string{"FIELD", "VALUE"})
+		for i := range names {
+			writeRow(tw, []string{names[i], values[i]})
+		}
+	default:
+		writeRow(tw, []string{formatValue(rv)})
+	}
+
+	return tw.Flush()
+}
+
+func writeRow(w io.Writer, cols []string) {
+	_, _ = fmt.Fprintln(w, strings.Join(cols, "\t"))
+}
+
+func fieldNames(t reflect.Type) (names []string) {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).PkgPath == "" {
+			names = append(names, strings.ToUpper(t.Field(i).Name))
+		}
+	}
+
+	return
+}
+
+func fieldValues(v reflect.Value) (values []string) {
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).PkgPath == "" {
+			values = append(values, formatValue(v.Field(i)))
+		}
+	}
+
+	return
+}
+
+// formatValue 格式化单元格的值，复合类型以 JSON 输出
+func formatValue(v reflect.Value) string {
+	v = indirect(v)
+	if !v.IsValid() {
+		return "-"
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.Struct, reflect.Interface:
+		data, err := json.Marshal(v.Interface())
+		if err != nil {
+			return fmt.Sprint(v.Interface())
+		}
+		return string(data)
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+
+	return v
+}
@@ -0,0 +1,94 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 17:30
+ * @Desc: 私聊消息命令
+ */
+
+package main
+
+import (
+	"flag"
+	"time"
+
+	"github.com/default-yarns/tencent-im"
+	"github.com/default-yarns/tencent-im/private"
+)
+
+var privateModule = &module{
+	name: "private",
+	desc: "私聊消息",
+	commands: []*command{
+		{
+			name:  "send",
+			desc:  "单发单聊文本消息",
+			usage: "<fromUserId> <toUserId> <text>",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				args, err := parseArgs(fs, args, 3, "fromUserId", "toUserId", "text")
+				if err != nil {
+					return nil, err
+				}
+
+				message := private.NewMessage()
+				message.SetSender(args[0])
+				message.SetReceivers(args[1])
+				message.SetContent(private.MsgTextContent{Text: args[2]})
+
+				return tim.Private().SendMessage(message)
+			},
+		},
+		{
+			name:  "messages",
+			desc:  "查询单聊消息",
+			usage: "[-limit 100] [-since 24h] <fromUserId> <toUserId>",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				limit := fs.Int("limit", 100, "拉取的消息条数")
+				since := fs.Duration("since", 24*time.Hour, "拉取最近多长时间内的消息")
+				args, err := parseArgs(fs, args, 2, "fromUserId", "toUserId")
+				if err != nil {
+					return nil, err
+				}
+
+				now := time.Now()
+				ret, err := tim.Private().FetchMessages(&private.FetchMessagesArg{
+					FromUserId: args[0],
+					ToUserId:   args[1],
+					MaxLimited: *limit,
+					MinTime:    now.Add(-*since).Unix(),
+					MaxTime:    now.Unix(),
+				})
+				if err != nil {
+					return nil, err
+				}
+
+				return ret.List, nil
+			},
+		},
+		{
+			name:  "revoke",
+			desc:  "撤回单聊消息",
+			usage: "<fromUserId> <toUserId> <msgKey>",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				args, err := parseArgs(fs, args, 3, "fromUserId", "toUserId", "msgKey")
+				if err != nil {
+					return nil, err
+				}
+
+				return nil, tim.Private().RevokeMessage(args[0], args[1], args[2])
+			},
+		},
+		{
+			name:  "unread",
+			desc:  "查询单聊未读消息计数",
+			usage: "<userId> [peerUserId...]",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				args, err := parseArgs(fs, args, 1, "userId")
+				if err != nil {
+					return nil, err
+				}
+
+				return tim.Private().GetUnreadMessageNum(args[0], args[1:]...)
+			},
+		},
+	},
+}
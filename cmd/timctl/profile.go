@@ -0,0 +1,82 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 17:10
+ * @Desc: 资料管理命令
+ */
+
+package main
+
+import (
+	"errors"
+	"flag"
+
+	"github.com/default-yarns/tencent-im"
+	"github.com/default-yarns/tencent-im/profile"
+)
+
+// profileView 资料输出
+type profileView struct {
+	UserId string                 `json:"userId"`
+	Attrs  map[string]interface{} `json:"attrs"`
+	Error  string                 `json:"error,omitempty"`
+}
+
+var profileModule = &module{
+	name: "profile",
+	desc: "资料管理",
+	commands: []*command{
+		{
+			name:  "get",
+			desc:  "拉取资料",
+			usage: "[-attrs Tag_Profile_IM_Nick,...] <userId>...",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				attrs := fs.String("attrs", profile.StandardAttrNickname+","+profile.StandardAttrAvatar, "需要拉取的资料字段，逗号分隔")
+				args, err := parseArgs(fs, args, 1, "userId")
+				if err != nil {
+					return nil, err
+				}
+
+				profiles, err := tim.Profile().GetProfiles(args, splitList(*attrs))
+				if err != nil {
+					return nil, err
+				}
+
+				views := make([]*profileView, 0, len(profiles))
+				for _, p := range profiles {
+					view := &profileView{UserId: p.GetUserId(), Attrs: p.GetAttrs()}
+					if err = p.GetError(); err != nil {
+						view.Error = err.Error()
+					}
+					views = append(views, view)
+				}
+
+				return views, nil
+			},
+		},
+		{
+			name:  "set",
+			desc:  "设置资料",
+			usage: "-attr Tag_Profile_IM_Nick=昵称 [-attr ...] <userId>",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				attrs := keyValues{}
+				fs.Var(attrs, "attr", "需要设置的资料字段，格式为 key=value，可重复设置")
+				args, err := parseArgs(fs, args, 1, "userId")
+				if err != nil {
+					return nil, err
+				}
+
+				if len(attrs) == 0 {
+					return nil, errors.New("the attributes is not set")
+				}
+
+				p := profile.NewProfile(args[0])
+				for k, v := range attrs {
+					p.SetAttr(k, v)
+				}
+
+				return nil, tim.Profile().SetProfile(p)
+			},
+		},
+	},
+}
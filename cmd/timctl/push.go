@@ -0,0 +1,62 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 17:30
+ * @Desc: 全员推送命令
+ */
+
+package main
+
+import (
+	"flag"
+
+	"github.com/default-yarns/tencent-im"
+	"github.com/default-yarns/tencent-im/push"
+)
+
+var pushModule = &module{
+	name: "push",
+	desc: "全员推送",
+	commands: []*command{
+		{
+			name:  "broadcast",
+			desc:  "全员推送文本消息",
+			usage: "[-title 离线推送标题] <fromUserId> <text>",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				title := fs.String("title", "", "离线推送标题")
+				args, err := parseArgs(fs, args, 2, "fromUserId", "text")
+				if err != nil {
+					return nil, err
+				}
+
+				message := push.NewMessage()
+				message.SetSender(args[0])
+				message.SetContent(push.MsgTextContent{Text: args[1]})
+				if *title != "" {
+					message.OfflinePush().SetTitle(*title)
+					message.OfflinePush().SetDesc(args[1])
+				}
+
+				taskId, err := tim.Push().PushMessage(message)
+				if err != nil {
+					return nil, err
+				}
+
+				return map[string]string{"taskId": taskId}, nil
+			},
+		},
+		{
+			name:  "tags",
+			desc:  "获取用户标签",
+			usage: "<userId>...",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				args, err := parseArgs(fs, args, 1, "userId")
+				if err != nil {
+					return nil, err
+				}
+
+				return tim.Push().GetUserTags(args...)
+			},
+		},
+	},
+}
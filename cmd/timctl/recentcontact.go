@@ -0,0 +1,67 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 17:30
+ * @Desc: 最近联系人命令
+ */
+
+package main
+
+import (
+	"flag"
+
+	"github.com/default-yarns/tencent-im"
+	"github.com/default-yarns/tencent-im/recentcontact"
+)
+
+var recentContactModule = &module{
+	name: "recentcontact",
+	desc: "最近联系人",
+	commands: []*command{
+		{
+			name:  "sessions",
+			desc:  "拉取全部会话",
+			usage: "[-top] [-empty] <userId>",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				top := fs.Bool("top", false, "是否支持置顶会话")
+				empty := fs.Bool("empty", false, "是否返回空会话")
+				args, err := parseArgs(fs, args, 1, "userId")
+				if err != nil {
+					return nil, err
+				}
+
+				sessions := make([]*recentcontact.SessionItem, 0)
+				err = tim.RecentContact().PullSessions(&recentcontact.PullSessionsArg{
+					UserId:                  args[0],
+					IsAllowTopSession:       *top,
+					IsReturnEmptySession:    *empty,
+					IsAllowTopSessionPaging: *top,
+				}, func(ret *recentcontact.FetchSessionsRet) {
+					sessions = append(sessions, ret.List...)
+				})
+
+				return sessions, err
+			},
+		},
+		{
+			name:  "delete",
+			desc:  "删除单个会话",
+			usage: "[-group] [-clear] <userId> <peerUserId|groupId>",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				isGroup := fs.Bool("group", false, "是否为群会话")
+				clear := fs.Bool("clear", false, "是否同时清理漫游消息")
+				args, err := parseArgs(fs, args, 2, "userId", "peerUserId|groupId")
+				if err != nil {
+					return nil, err
+				}
+
+				sessionType := recentcontact.SessionTypeC2C
+				if *isGroup {
+					sessionType = recentcontact.SessionTypeG2C
+				}
+
+				return nil, tim.RecentContact().DeleteSession(args[0], args[1], sessionType, *clear)
+			},
+		},
+	},
+}
@@ -0,0 +1,106 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 17:10
+ * @Desc: 关系链管理命令
+ */
+
+package main
+
+import (
+	"flag"
+
+	"github.com/default-yarns/tencent-im"
+	"github.com/default-yarns/tencent-im/sns"
+)
+
+// friendView 好友输出
+type friendView struct {
+	UserId string                 `json:"userId"`
+	Attrs  map[string]interface{} `json:"attrs"`
+}
+
+var snsModule = &module{
+	name: "sns",
+	desc: "关系链管理",
+	commands: []*command{
+		{
+			name:  "friends",
+			desc:  "拉取全部好友",
+			usage: "<userId>",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				args, err := parseArgs(fs, args, 1, "userId")
+				if err != nil {
+					return nil, err
+				}
+
+				views := make([]*friendView, 0)
+				err = tim.SNS().PullFriends(args[0], func(ret *sns.FetchFriendsRet) {
+					for _, friend := range ret.List {
+						views = append(views, &friendView{UserId: friend.GetUserId(), Attrs: friend.GetSNSAttrs()})
+					}
+				})
+
+				return views, err
+			},
+		},
+		{
+			name:  "delete-friend",
+			desc:  "删除好友",
+			usage: "[-both] <userId> <friendUserId>...",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				both := fs.Bool("both", false, "是否双向删除")
+				args, err := parseArgs(fs, args, 2, "userId", "friendUserId")
+				if err != nil {
+					return nil, err
+				}
+
+				return tim.SNS().DeleteFriends(args[0], *both, args[1:]...)
+			},
+		},
+		{
+			name:  "blacklist",
+			desc:  "拉取全部黑名单",
+			usage: "<userId>",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				args, err := parseArgs(fs, args, 1, "userId")
+				if err != nil {
+					return nil, err
+				}
+
+				list := make([]*sns.Blacklist, 0)
+				err = tim.SNS().PullBlacklist(args[0], 1000, func(ret *sns.FetchBlacklistRet) {
+					list = append(list, ret.List...)
+				})
+
+				return list, err
+			},
+		},
+		{
+			name:  "add-blacklist",
+			desc:  "添加黑名单",
+			usage: "<userId> <blackedUserId>...",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				args, err := parseArgs(fs, args, 2, "userId", "blackedUserId")
+				if err != nil {
+					return nil, err
+				}
+
+				return tim.SNS().AddBlacklist(args[0], args[1:]...)
+			},
+		},
+		{
+			name:  "delete-blacklist",
+			desc:  "删除黑名单",
+			usage: "<userId> <blackedUserId>...",
+			run: func(tim im.IM, fs *flag.FlagSet, args []string) (interface{}, error) {
+				args, err := parseArgs(fs, args, 2, "userId", "blackedUserId")
+				if err != nil {
+					return nil, err
+				}
+
+				return tim.SNS().DeleteBlacklist(args[0], args[1:]...)
+			},
+		},
+	},
+}
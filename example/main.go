@@ -8,12 +8,15 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/default-yarns/tencent-im"
 	"github.com/default-yarns/tencent-im/account"
+	"github.com/default-yarns/tencent-im/callback"
 )
 
 func main() {
@@ -38,22 +41,23 @@ func main() {
 
 	fmt.Println("import account success.")
 
-	//// 注册回调事件
-	//tim.Callback().Register(callback.EventAfterFriendAdd, func(ack callback.Ack, data interface{}) {
-	//	fmt.Printf("%+v", data.(callback.AfterFriendAdd))
-	//	_ = ack.AckSuccess(0)
-	//})
-	//
-	//// 注册回调事件
-	//tim.Callback().Register(callback.EventAfterFriendDelete, func(ack callback.Ack, data interface{}) {
-	//	fmt.Printf("%+v", data.(callback.AfterFriendDelete))
-	//	_ = ack.AckSuccess(0)
-	//})
+	// 注册回调事件
+	tim.Callback().OnAfterFriendAdd(func(ctx context.Context, ack callback.Ack, data *callback.AfterFriendAdd) {
+		fmt.Printf("%+v", data)
+		_ = ack.AckSuccess(0)
+	})
+
+	// 注册回调事件
+	tim.Callback().OnAfterFriendDelete(func(ctx context.Context, ack callback.Ack, data *callback.AfterFriendDelete) {
+		fmt.Printf("%+v", data)
+		_ = ack.AckSuccess(0)
+	})
 
 	// 开启监听
-	//http.HandleFunc("/callback", func(writer http.ResponseWriter, request *http.Request) {
-	//	tim.Callback().Listen(writer, request)
-	//})
+	http.Handle("/callback", tim.Callback().Handler(&callback.HandlerOptions{
+		MaxBodySize: 1 << 20,
+		Timeout:     3 * time.Second,
+	}))
 
 	// 启动服务器
 	if err := http.ListenAndServe(":8080", nil); err != nil {
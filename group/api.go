@@ -18,6 +18,7 @@ import (
 
 const (
 	serviceGroup                       = "group_open_http_svc"
+	serviceAVChatRoom                  = "group_open_avchatroom_http_svc"
 	commandFetchGroupIds               = "get_appid_group_list"
 	commandCreateGroup                 = "create_group"
 	commandDestroyGroup                = "destroy_group"
@@ -31,10 +32,15 @@ const (
 	commandGetRoleInGroup              = "get_role_in_group"
 	commandForbidSendMsg               = "forbid_send_msg"
 	commandGetGroupShuttedUin          = "get_group_shutted_uin"
+	commandBanGroupMember              = "ban_group_member"
+	commandUnbanGroupMember            = "unban_group_member"
+	commandGetGroupBanMember           = "get_group_ban_member"
 	commandSendGroupMsg                = "send_group_msg"
 	commandSendGroupSystemNotification = "send_group_system_notification"
 	commandChangeGroupOwner            = "change_group_owner"
 	commandRecallGroupMsg              = "group_msg_recall"
+	commandDeleteGroupMsg              = "delete_group_msg"
+	commandClearGroupMsg               = "clear_group_msg"
 	commandImportGroup                 = "import_group"
 	commandImportGroupMsg              = "import_group_msg"
 	commandImportGroupMember           = "import_group_member"
@@ -42,6 +48,10 @@ const (
 	commandDeleteGroupMsgBySender      = "delete_group_msg_by_sender"
 	commandGetGroupSimpleMsg           = "group_msg_get_simple"
 	commandGetOnlineMemberNum          = "get_online_member_num"
+	commandGetOnlineMembers            = "get_members"
+	commandSendBroadcastMsg            = "send_broadcast_msg"
+	commandSetKeyValues                = "set_key_values"
+	commandGetKeyValues                = "get_key_values"
 
 	batchGetGroupsLimit = 50 // 批量获取群组限制
 )
@@ -165,6 +175,31 @@ type API interface {
 	// https://cloud.tencent.com/document/product/269/2925
 	GetShuttedUpMembers(groupId string) (shuttedUps map[string]int64, err error)
 
+	// BanMembers 群成员封禁
+	// App 管理员可以通过该接口将群成员踢出群组，并在封禁时长内禁止其再次加入该群组。
+	// 目前仅支持直播群（AVChatRoom）。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/91390
+	BanMembers(groupId string, userIds []string, duration int64, description ...string) (err error)
+
+	// UnbanMembers 群成员解封
+	// App 管理员可以通过该接口解除对群成员的封禁，解封后用户可以再次加入该群组。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/91391
+	UnbanMembers(groupId string, userIds []string) (err error)
+
+	// FetchBannedMembers 获取封禁群成员列表
+	// App 管理员可以根据群组ID分页获取群组中被封禁的用户列表。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/91392
+	FetchBannedMembers(groupId string, limit, offset int) (ret *FetchBannedMembersRet, err error)
+
+	// PullBannedMembers 续拉取封禁群成员列表
+	// 本方法由“获取封禁群成员列表（FetchBannedMembers）”拓展而来
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/91392
+	PullBannedMembers(arg *PullBannedMembersArg, fn func(ret *FetchBannedMembersRet)) (err error)
+
 	// SendMessage 在群组中发送普通消息
 	// App管理员可以通过该接口在群组中发送普通消息。
 	// 点击查看详细文档:
@@ -197,6 +232,24 @@ type API interface {
 	// https://cloud.tencent.com/document/product/269/12341
 	RevokeMessages(groupId string, msgSeq ...int) (results map[int]int, err error)
 
+	// DeleteMessage 删除单条群消息
+	// 本方法由“删除多条群消息（DeleteMessages）”拓展而来
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/105853
+	DeleteMessage(groupId string, msgSeq int) (err error)
+
+	// DeleteMessages 删除多条群消息
+	// App 管理员通过该接口删除指定群组的漫游消息，删除后所有群成员均无法再拉取到这些消息。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/105853
+	DeleteMessages(groupId string, msgSeq ...int) (results map[int]int, err error)
+
+	// ClearMessages 清空群历史消息
+	// App 管理员通过该接口清空指定群组的全部漫游消息。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/105854
+	ClearMessages(groupId string) (err error)
+
 	// ImportGroup 导入群基础资料
 	// App 管理员可以通过该接口导入群组，不会触发回调、不会下发通知；当 App 需要从其他即时通信系统迁移到即时通信 IM 时，使用该协议导入存量群组数据。
 	// 点击查看详细文档:
@@ -252,6 +305,58 @@ type API interface {
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/49180
 	GetOnlineMemberNum(groupId string) (num int, err error)
+
+	// FetchOnlineMembers 获取直播群在线成员列表
+	// App 管理员可以根据群组 ID 分页获取直播群的在线成员列表，返回的成员信息中包含成员的自定义字段。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/77266
+	FetchOnlineMembers(groupId string, next ...int64) (ret *FetchOnlineMembersRet, err error)
+
+	// PullOnlineMembers 续拉取直播群在线成员列表
+	// 本方法由“获取直播群在线成员列表（FetchOnlineMembers）”拓展而来
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/77266
+	PullOnlineMembers(groupId string, fn func(ret *FetchOnlineMembersRet)) (err error)
+
+	// SendBroadcastMessage 直播群广播消息
+	// App 管理员可以通过该接口向 App 中的所有直播群广播消息。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/61444
+	SendBroadcastMessage(message *Message) (err error)
+
+	// SetMessageExtensions 设置群消息扩展
+	// App 管理员可以通过该接口设置支持消息扩展的群消息的扩展项（如表情回复、投票等）。
+	// 消息扩展采用乐观锁控制并发：每个扩展项需携带当前最新的版本号（Seq），新增的键填0。
+	// 若版本号与后台不一致，该扩展项设置失败，返回结果中携带后台最新的扩展项，可据此重新设置。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/84082
+	SetMessageExtensions(groupId string, msgSeq int, extensions ...*MessageExtension) (results []MessageExtensionResult, err error)
+
+	// DeleteMessageExtensions 删除群消息扩展
+	// 本方法由“设置群消息扩展（SetMessageExtensions）”拓展而来
+	// 删除扩展项同样需要携带当前最新的版本号（Seq）。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/84082
+	DeleteMessageExtensions(groupId string, msgSeq int, extensions ...*MessageExtension) (results []MessageExtensionResult, err error)
+
+	// ClearMessageExtensions 清空群消息扩展
+	// 本方法由“设置群消息扩展（SetMessageExtensions）”拓展而来
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/84082
+	ClearMessageExtensions(groupId string, msgSeq int) (err error)
+
+	// FetchMessageExtensions 获取群消息扩展
+	// App 管理员可以通过该接口获取群消息的扩展项。
+	// 若扩展项较多，需要根据返回的 NextSeq 进行续拉。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/84083
+	FetchMessageExtensions(groupId string, msgSeq int, startSeq ...int) (ret *FetchMessageExtensionsRet, err error)
+
+	// PullMessageExtensions 续拉取群消息扩展
+	// 本方法由“获取群消息扩展（FetchMessageExtensions）”拓展而来
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/84083
+	PullMessageExtensions(groupId string, msgSeq int, fn func(ret *FetchMessageExtensionsRet)) (err error)
 }
 
 type api struct {
@@ -360,7 +465,6 @@ func (a *api) PullGroups(arg *PullGroupsArg, fn func(ret *FetchGroupsRet)) (err
 
 		if ret.HasMore {
 			next = ret.Next
-			break
 		}
 	}
 
@@ -833,6 +937,7 @@ func (a *api) FetchMemberGroups(arg *FetchMemberGroupsArg) (ret *FetchMemberGrou
 				msgSeq:          item.MemberInfo.MsgSeq,
 				msgFlag:         MsgFlag(item.MemberInfo.MsgFlag),
 				lastSendMsgTime: item.MemberInfo.LastSendMsgTime,
+				unreadMsgNum:    item.MemberInfo.UnreadMsgNum,
 			}
 
 			if item.MemberInfo.AppMemberDefinedData != nil && len(item.MemberInfo.AppMemberDefinedData) > 0 {
@@ -952,6 +1057,103 @@ func (a *api) GetShuttedUpMembers(groupId string) (shuttedUps map[string]int64,
 	return
 }
 
+// BanMembers 群成员封禁
+// App 管理员可以通过该接口将群成员踢出群组，并在封禁时长内禁止其再次加入该群组。
+// 目前仅支持直播群（AVChatRoom）。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/91390
+func (a *api) BanMembers(groupId string, userIds []string, duration int64, description ...string) (err error) {
+	if len(userIds) == 0 {
+		err = core.NewError(enum.InvalidParamsCode, "the userIds is not set")
+		return
+	}
+
+	req := &banMembersReq{
+		GroupId:  groupId,
+		UserIds:  userIds,
+		Duration: duration,
+	}
+
+	if len(description) > 0 {
+		req.Description = description[0]
+	}
+
+	if err = a.client.Post(serviceGroup, commandBanGroupMember, req, &types.ActionBaseResp{}); err != nil {
+		return
+	}
+
+	return
+}
+
+// UnbanMembers 群成员解封
+// App 管理员可以通过该接口解除对群成员的封禁，解封后用户可以再次加入该群组。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/91391
+func (a *api) UnbanMembers(groupId string, userIds []string) (err error) {
+	if len(userIds) == 0 {
+		err = core.NewError(enum.InvalidParamsCode, "the userIds is not set")
+		return
+	}
+
+	req := &unbanMembersReq{
+		GroupId: groupId,
+		UserIds: userIds,
+	}
+
+	if err = a.client.Post(serviceGroup, commandUnbanGroupMember, req, &types.ActionBaseResp{}); err != nil {
+		return
+	}
+
+	return
+}
+
+// FetchBannedMembers 获取封禁群成员列表
+// App 管理员可以根据群组ID分页获取群组中被封禁的用户列表。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/91392
+func (a *api) FetchBannedMembers(groupId string, limit, offset int) (ret *FetchBannedMembersRet, err error) {
+	req := &fetchBannedMembersReq{GroupId: groupId, Limit: limit, Offset: offset}
+	resp := &fetchBannedMembersResp{}
+
+	if err = a.client.Post(serviceGroup, commandGetGroupBanMember, req, resp); err != nil {
+		return
+	}
+
+	ret = &FetchBannedMembersRet{
+		Next:    resp.NextOffset,
+		HasMore: resp.NextOffset != 0,
+		List:    resp.List,
+	}
+
+	return
+}
+
+// PullBannedMembers 续拉取封禁群成员列表
+// 本方法由“获取封禁群成员列表（FetchBannedMembers）”拓展而来
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/91392
+func (a *api) PullBannedMembers(arg *PullBannedMembersArg, fn func(ret *FetchBannedMembersRet)) (err error) {
+	var (
+		offset int
+		ret    *FetchBannedMembersRet
+	)
+
+	for ret == nil || ret.HasMore {
+		ret, err = a.FetchBannedMembers(arg.GroupId, arg.Limit, offset)
+		if err != nil {
+			return
+		}
+
+		fn(ret)
+
+		if ret.HasMore {
+			offset = ret.Next
+		}
+	}
+
+	return
+}
+
 // SendMessage 在群组中发送普通消息
 // App管理员可以通过该接口在群组中发送普通消息。
 // 点击查看详细文档:
@@ -972,6 +1174,7 @@ func (a *api) SendMessage(groupId string, message *Message) (ret *SendMessageRet
 	req.SendMsgControl = message.GetSendMsgControl()
 	req.ForbidCallbackControl = message.GetForbidCallbackControl()
 	req.OnlineOnlyFlag = int(message.GetOnlineOnlyFlag())
+	req.SupportExtension = message.GetSupportMessageExtension()
 
 	if message.atMembers != nil && len(message.atMembers) > 0 {
 		req.GroupAtInfo = make([]atInfo, 0, len(message.atMembers))
@@ -1081,6 +1284,72 @@ func (a *api) RevokeMessages(groupId string, msgSeq ...int) (results map[int]int
 	return
 }
 
+// DeleteMessage 删除单条群消息
+// 本方法由“删除多条群消息（DeleteMessages）”拓展而来
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/105853
+func (a *api) DeleteMessage(groupId string, msgSeq int) (err error) {
+	var results map[int]int
+
+	if results, err = a.DeleteMessages(groupId, msgSeq); err != nil {
+		return
+	}
+
+	if results[msgSeq] != enum.SuccessCode {
+		err = core.NewError(results[msgSeq], "message delete failed")
+		return
+	}
+
+	return
+}
+
+// DeleteMessages 删除多条群消息
+// App 管理员通过该接口删除指定群组的漫游消息，删除后所有群成员均无法再拉取到这些消息。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/105853
+func (a *api) DeleteMessages(groupId string, msgSeq ...int) (results map[int]int, err error) {
+	if len(msgSeq) == 0 {
+		err = core.NewError(enum.InvalidParamsCode, "the message seq is not set")
+		return
+	}
+
+	req := deleteMessagesReq{}
+	req.GroupId = groupId
+	req.MsgSeqList = make([]msgSeqItem, 0, len(msgSeq))
+	for _, seq := range msgSeq {
+		req.MsgSeqList = append(req.MsgSeqList, msgSeqItem{
+			MsgSeq: seq,
+		})
+	}
+
+	resp := &deleteMessagesResp{}
+
+	if err = a.client.Post(serviceGroup, commandDeleteGroupMsg, req, resp); err != nil {
+		return
+	}
+
+	results = make(map[int]int)
+	for _, item := range resp.Results {
+		results[item.MsgSeq] = item.RetCode
+	}
+
+	return
+}
+
+// ClearMessages 清空群历史消息
+// App 管理员通过该接口清空指定群组的全部漫游消息。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/105854
+func (a *api) ClearMessages(groupId string) (err error) {
+	req := &clearMessagesReq{GroupId: groupId}
+
+	if err = a.client.Post(serviceGroup, commandClearGroupMsg, req, &types.ActionBaseResp{}); err != nil {
+		return
+	}
+
+	return
+}
+
 // ImportGroup 导入群基础资料
 // App 管理员可以通过该接口导入群组，不会触发回调、不会下发通知；当 App 需要从其他即时通信系统迁移到即时通信 IM 时，使用该协议导入存量群组数据。
 // 点击查看详细文档:
@@ -1263,6 +1532,14 @@ func (a *api) FetchMessages(groupId string, limit int, msgSeq ...int) (ret *Fetc
 		case 4:
 			message.priority = MsgPriorityLowest
 		}
+
+		body := make([]*types.MsgBody, 0, len(item.MsgBody))
+		for i := range item.MsgBody {
+			body = append(body, &item.MsgBody[i])
+		}
+		message.SetBody(body...)
+
+		ret.List = append(ret.List, message)
 	}
 
 	return
@@ -1313,3 +1590,205 @@ func (a *api) GetOnlineMemberNum(groupId string) (num int, err error) {
 
 	return
 }
+
+// FetchOnlineMembers 获取直播群在线成员列表
+// App 管理员可以根据群组 ID 分页获取直播群的在线成员列表，返回的成员信息中包含成员的自定义字段。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/77266
+func (a *api) FetchOnlineMembers(groupId string, next ...int64) (ret *FetchOnlineMembersRet, err error) {
+	req := &fetchOnlineMembersReq{GroupId: groupId}
+
+	if len(next) > 0 {
+		req.Timestamp = next[0]
+	}
+
+	resp := &fetchOnlineMembersResp{}
+
+	if err = a.client.Post(serviceAVChatRoom, commandGetOnlineMembers, req, resp); err != nil {
+		return
+	}
+
+	ret = &FetchOnlineMembersRet{}
+	ret.Next = resp.NextTimestamp
+	ret.HasMore = resp.NextTimestamp != 0
+	ret.List = make([]*Member, 0, len(resp.MemberList))
+
+	for _, m := range resp.MemberList {
+		member := &Member{
+			userId:   m.UserId,
+			role:     m.Role,
+			joinTime: m.JoinTime,
+			nameCard: m.NameCard,
+		}
+
+		if m.AppMemberDefinedData != nil && len(m.AppMemberDefinedData) > 0 {
+			for _, v := range m.AppMemberDefinedData {
+				member.SetCustomData(v.Key, v.Value)
+			}
+		}
+
+		ret.List = append(ret.List, member)
+	}
+
+	return
+}
+
+// PullOnlineMembers 续拉取直播群在线成员列表
+// 本方法由“获取直播群在线成员列表（FetchOnlineMembers）”拓展而来
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/77266
+func (a *api) PullOnlineMembers(groupId string, fn func(ret *FetchOnlineMembersRet)) (err error) {
+	var (
+		next int64
+		ret  *FetchOnlineMembersRet
+	)
+
+	for ret == nil || ret.HasMore {
+		ret, err = a.FetchOnlineMembers(groupId, next)
+		if err != nil {
+			return
+		}
+
+		fn(ret)
+
+		if ret.HasMore {
+			next = ret.Next
+		}
+	}
+
+	return
+}
+
+// SendBroadcastMessage 直播群广播消息
+// App 管理员可以通过该接口向 App 中的所有直播群广播消息。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/61444
+func (a *api) SendBroadcastMessage(message *Message) (err error) {
+	if err = message.checkSendError(); err != nil {
+		return
+	}
+
+	req := &sendBroadcastMessageReq{}
+	req.FromUserId = message.GetSender()
+	req.MsgBody = message.GetBody()
+	req.Random = message.GetRandom()
+	req.CloudCustomData = conv.String(message.GetCustomData())
+
+	if err = a.client.Post(serviceGroup, commandSendBroadcastMsg, req, &types.ActionBaseResp{}); err != nil {
+		return
+	}
+
+	return
+}
+
+// SetMessageExtensions 设置群消息扩展
+// App 管理员可以通过该接口设置支持消息扩展的群消息的扩展项（如表情回复、投票等）。
+// 消息扩展采用乐观锁控制并发：每个扩展项需携带当前最新的版本号（Seq），新增的键填0。
+// 若版本号与后台不一致，该扩展项设置失败，返回结果中携带后台最新的扩展项，可据此重新设置。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/84082
+func (a *api) SetMessageExtensions(groupId string, msgSeq int, extensions ...*MessageExtension) (results []MessageExtensionResult, err error) {
+	return a.operateMessageExtensions(groupId, msgSeq, enum.MsgExtensionOperateSet, extensions...)
+}
+
+// DeleteMessageExtensions 删除群消息扩展
+// 本方法由“设置群消息扩展（SetMessageExtensions）”拓展而来
+// 删除扩展项同样需要携带当前最新的版本号（Seq）。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/84082
+func (a *api) DeleteMessageExtensions(groupId string, msgSeq int, extensions ...*MessageExtension) (results []MessageExtensionResult, err error) {
+	return a.operateMessageExtensions(groupId, msgSeq, enum.MsgExtensionOperateDelete, extensions...)
+}
+
+// ClearMessageExtensions 清空群消息扩展
+// 本方法由“设置群消息扩展（SetMessageExtensions）”拓展而来
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/84082
+func (a *api) ClearMessageExtensions(groupId string, msgSeq int) (err error) {
+	_, err = a.operateMessageExtensions(groupId, msgSeq, enum.MsgExtensionOperateClear)
+	return
+}
+
+// operateMessageExtensions 操作群消息扩展
+func (a *api) operateMessageExtensions(groupId string, msgSeq int, operateType int, extensions ...*MessageExtension) (results []MessageExtensionResult, err error) {
+	if operateType != enum.MsgExtensionOperateClear && len(extensions) == 0 {
+		err = core.NewError(enum.InvalidParamsCode, "the message extensions is not set")
+		return
+	}
+
+	req := &setMessageExtensionsReq{
+		GroupId:       groupId,
+		MsgSeq:        msgSeq,
+		OperateType:   operateType,
+		ExtensionList: extensions,
+	}
+	resp := &setMessageExtensionsResp{}
+
+	if err = a.client.Post(serviceGroup, commandSetKeyValues, req, resp); err != nil {
+		return
+	}
+
+	results = resp.Results
+
+	return
+}
+
+// FetchMessageExtensions 获取群消息扩展
+// App 管理员可以通过该接口获取群消息的扩展项。
+// 若扩展项较多，需要根据返回的 NextSeq 进行续拉。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/84083
+func (a *api) FetchMessageExtensions(groupId string, msgSeq int, startSeq ...int) (ret *FetchMessageExtensionsRet, err error) {
+	req := &fetchMessageExtensionsReq{GroupId: groupId, MsgSeq: msgSeq}
+
+	if len(startSeq) > 0 {
+		req.StartSeq = startSeq[0]
+	}
+
+	resp := &fetchMessageExtensionsResp{}
+
+	if err = a.client.Post(serviceGroup, commandGetKeyValues, req, resp); err != nil {
+		return
+	}
+
+	ret = &FetchMessageExtensionsRet{
+		LatestSeq: resp.LatestSeq,
+		ClearSeq:  resp.ClearSeq,
+		HasMore:   resp.Complete == 0,
+		List:      resp.ExtensionList,
+	}
+
+	if count := len(resp.ExtensionList); count > 0 {
+		ret.NextSeq = resp.ExtensionList[count-1].Seq + 1
+	} else {
+		ret.HasMore = false
+	}
+
+	return
+}
+
+// PullMessageExtensions 续拉取群消息扩展
+// 本方法由“获取群消息扩展（FetchMessageExtensions）”拓展而来
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/84083
+func (a *api) PullMessageExtensions(groupId string, msgSeq int, fn func(ret *FetchMessageExtensionsRet)) (err error) {
+	var (
+		ret      *FetchMessageExtensionsRet
+		startSeq int
+	)
+
+	for ret == nil || ret.HasMore {
+		ret, err = a.FetchMessageExtensions(groupId, msgSeq, startSeq)
+		if err != nil {
+			return
+		}
+
+		fn(ret)
+
+		if ret.HasMore {
+			startSeq = ret.NextSeq
+		}
+	}
+
+	return
+}
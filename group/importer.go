@@ -0,0 +1,515 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 15:00
+ * @Desc: 群消息批量导入
+ */
+
+package group
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/default-yarns/tencent-im/internal/checkpoint"
+	"github.com/default-yarns/tencent-im/internal/core"
+	"github.com/default-yarns/tencent-im/internal/enum"
+	"github.com/default-yarns/tencent-im/internal/limiter"
+)
+
+const (
+	batchImportMessagesLimit   = 7           // 每次导入群消息的最大条数
+	defaultImportConcurrency   = 5           // 默认并发导入的群组数
+	defaultImportRateLimit     = 200         // 默认每秒调用导入接口的次数
+	defaultImportMaxRetries    = 3           // 默认的最大重试次数
+	defaultImportRetryInterval = time.Second // 默认的重试间隔
+)
+
+// ImportStatus 导入状态
+type ImportStatus string
+
+const (
+	ImportStatusSucceeded  ImportStatus = "succeeded"  // 导入成功
+	ImportStatusFailed     ImportStatus = "failed"     // 导入失败
+	ImportStatusSkipped    ImportStatus = "skipped"    // 断点记录中已导入，跳过
+	ImportStatusDuplicated ImportStatus = "duplicated" // 与其他消息重复，跳过
+)
+
+// ErrImportAborted 同一群组内之前的消息导入失败，为保证消息顺序不再导入该群组的后续消息
+var ErrImportAborted = errors.New("import aborted because a previous message of the group failed")
+
+// errImportBatchFailed 批次中存在最终导入失败的消息
+var errImportBatchFailed = errors.New("import group message batch failed")
+
+// 不可重试的单条消息导入结果
+var importPermanentResults = map[int]string{
+	10004: "invalid message send time",
+	80001: "message contains dirty words",
+	80002: "message is too long",
+}
+
+type (
+	// ImporterOptions 批量导入配置
+	ImporterOptions struct {
+		Concurrency    int           // （选填）并发导入的群组数，默认为5
+		RateLimit      int           // （选填）每秒最多调用导入接口的次数，默认为200
+		MaxRetries     int           // （选填）导入失败时的最大重试次数，默认为3，小于0时不重试
+		RetryInterval  time.Duration // （选填）重试间隔，默认为1秒
+		CheckpointFile string        // （选填）断点文件路径，为空时不记录断点
+	}
+
+	// ImportEntry 待导入的群消息
+	ImportEntry struct {
+		GroupId string   // 群ID
+		Message *Message // 群消息
+	}
+
+	// ImportResult 单条群消息导入结果
+	ImportResult struct {
+		GroupId    string       // 群ID
+		FromUserId string       // 消息发送方 UserID
+		SendTime   int64        // 消息发送时间
+		MsgRandom  uint32       // 消息随机数
+		MsgSeq     int          // 导入成功后的消息序列号
+		Status     ImportStatus // 导入状态
+		Attempts   int          // 调用导入接口的次数
+		Error      error        // 导入失败的错误信息
+	}
+
+	// ImportReport 批量导入报告
+	ImportReport struct {
+		Total      int             // 消息总数
+		Succeeded  int             // 导入成功数
+		Failed     int             // 导入失败数
+		Skipped    int             // 断点跳过数
+		Duplicated int             // 重复消息数
+		Results    []*ImportResult // 逐条消息的导入结果，按群组及导入顺序排列，重复消息排在最后
+	}
+
+	// Importer 群消息批量导入器
+	// 本导入器由“导入群消息（ImportMessages）”拓展而来。
+	// 每个群组内的消息按发送时间升序排列，并按每批最多7条分批导入；不同群组之间并发导入，同一群组内严格按顺序导入。
+	// 整批导入失败或单条消息导入结果为可重试的错误时，将按配置重试；
+	// 群组内任一消息最终导入失败后，该群组的后续消息均记为失败（ErrImportAborted），以免导入的历史消息缺失或乱序。
+	// 消息按群ID、发送方、发送时间与消息内容去重，消息标识不包含随机数，未设置随机数不影响去重与断点续传。
+	Importer struct {
+		api     API
+		opt     ImporterOptions
+		mu      sync.Mutex
+		keys    map[string]struct{}
+		groups  map[string][]*importItem
+		orders  []string
+		repeats []*importItem
+		smu     sync.Mutex
+		scp     *checkpoint.Checkpoint // 流式导入共用的断点记录
+		slimit  *limiter.Limiter       // 流式导入共用的频率限制器
+	}
+
+	importItem struct {
+		key     string
+		message *Message
+		result  *ImportResult
+	}
+)
+
+func NewImporter(api API, opt ...*ImporterOptions) *Importer {
+	i := &Importer{
+		api:    api,
+		keys:   make(map[string]struct{}),
+		groups: make(map[string][]*importItem),
+	}
+
+	if len(opt) > 0 && opt[0] != nil {
+		i.opt = *opt[0]
+	}
+
+	if i.opt.Concurrency <= 0 {
+		i.opt.Concurrency = defaultImportConcurrency
+	}
+
+	if i.opt.RateLimit <= 0 {
+		i.opt.RateLimit = defaultImportRateLimit
+	}
+
+	if i.opt.MaxRetries == 0 {
+		i.opt.MaxRetries = defaultImportMaxRetries
+	} else if i.opt.MaxRetries < 0 {
+		i.opt.MaxRetries = 0
+	}
+
+	if i.opt.RetryInterval <= 0 {
+		i.opt.RetryInterval = defaultImportRetryInterval
+	}
+
+	return i
+}
+
+// Add 添加待导入的群消息
+func (i *Importer) Add(groupId string, messages ...*Message) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for _, message := range messages {
+		item := newImportItem(groupId, message)
+
+		if _, ok := i.keys[item.key]; ok {
+			item.result.Status = ImportStatusDuplicated
+			i.repeats = append(i.repeats, item)
+			continue
+		}
+
+		if _, ok := i.groups[groupId]; !ok {
+			i.orders = append(i.orders, groupId)
+		}
+
+		i.keys[item.key] = struct{}{}
+		i.groups[groupId] = append(i.groups[groupId], item)
+	}
+}
+
+// AddStream 从消息流中读取待导入的群消息，直到消息流关闭
+func (i *Importer) AddStream(stream <-chan *ImportEntry) {
+	for entry := range stream {
+		i.Add(entry.GroupId, entry.Message)
+	}
+}
+
+// Run 执行批量导入
+// 单条消息导入失败不会中断其他群组的导入，失败原因记录在导入报告中；仅在断点文件读写失败时返回错误。
+// 导入失败的消息不会记录到断点文件中，再次执行时将重新导入。
+func (i *Importer) Run() (report *ImportReport, err error) {
+	return i.RunContext(context.Background())
+}
+
+// RunContext 执行批量导入，上下文结束时停止导入与重试等待，未导入的消息记为失败并返回上下文的错误
+func (i *Importer) RunContext(ctx context.Context) (report *ImportReport, err error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	cp, err := checkpoint.Open(i.opt.CheckpointFile)
+	if err != nil {
+		return
+	}
+	defer cp.Close()
+
+	var (
+		wg    sync.WaitGroup
+		once  sync.Once
+		queue = make(chan string)
+		limit = limiter.New(i.opt.RateLimit)
+	)
+	defer limit.Stop()
+
+	for n := 0; n < i.opt.Concurrency; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for groupId := range queue {
+				if e := i.importGroup(ctx, cp, limit, groupId, i.groups[groupId]); e != nil {
+					once.Do(func() { err = e })
+				}
+			}
+		}()
+	}
+
+	for _, groupId := range i.orders {
+		queue <- groupId
+	}
+	close(queue)
+	wg.Wait()
+
+	report = &ImportReport{}
+	items := make([]*importItem, 0, len(i.keys)+len(i.repeats))
+	for _, groupId := range i.orders {
+		items = append(items, i.groups[groupId]...)
+	}
+	items = append(items, i.repeats...)
+
+	for _, item := range items {
+		report.Total++
+		switch item.result.Status {
+		case ImportStatusSucceeded:
+			report.Succeeded++
+		case ImportStatusFailed:
+			report.Failed++
+		case ImportStatusSkipped:
+			report.Skipped++
+		case ImportStatusDuplicated:
+			report.Duplicated++
+		}
+		report.Results = append(report.Results, item.result)
+	}
+
+	return
+}
+
+// importGroup 按顺序导入单个群组的消息，任一消息最终导入失败后不再导入该群组的后续消息
+func (i *Importer) importGroup(ctx context.Context, cp *checkpoint.Checkpoint, limit *limiter.Limiter, groupId string, items []*importItem) error {
+	sort.SliceStable(items, func(a, b int) bool {
+		return items[a].result.SendTime < items[b].result.SendTime
+	})
+
+	for _, item := range items {
+		item.result.Status = ""
+		item.result.Attempts = 0
+		item.result.Error = nil
+
+		if cp.Done(item.key) {
+			item.result.Status = ImportStatusSkipped
+		}
+	}
+
+	pending := make([]*importItem, 0, len(items))
+	for idx, item := range items {
+		if item.result.Status == ImportStatusSkipped {
+			continue
+		}
+
+		if err := item.message.checkImportError(); err != nil {
+			item.result.Status = ImportStatusFailed
+			item.result.Error = err
+			abortImportItems(items[idx+1:], ErrImportAborted)
+			break
+		}
+
+		pending = append(pending, item)
+	}
+
+	for len(pending) > 0 {
+		end := batchImportMessagesLimit
+		if end > len(pending) {
+			end = len(pending)
+		}
+
+		if err := i.importBatch(ctx, cp, limit, groupId, pending[:end]); err != nil {
+			switch err {
+			case errImportBatchFailed:
+				abortImportItems(pending[end:], ErrImportAborted)
+				return nil
+			case ctx.Err():
+				abortImportItems(pending, err)
+			}
+			return err
+		}
+
+		pending = pending[end:]
+	}
+
+	return nil
+}
+
+// importBatch 导入一批群消息，并对失败的消息进行重试
+// 批次中存在最终导入失败的消息时返回 errImportBatchFailed，上下文结束时返回上下文的错误
+func (i *Importer) importBatch(ctx context.Context, cp *checkpoint.Checkpoint, limit *limiter.Limiter, groupId string, batch []*importItem) error {
+	failed := false
+
+	for attempt := 0; len(batch) > 0; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(i.opt.RetryInterval)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
+		}
+
+		if err := limit.WaitContext(ctx); err != nil {
+			return err
+		}
+
+		messages := make([]*Message, 0, len(batch))
+		for _, item := range batch {
+			item.result.Attempts++
+			messages = append(messages, item.message)
+		}
+
+		results, err := i.api.ImportMessages(groupId, messages...)
+		if err != nil {
+			if attempt < i.opt.MaxRetries && isRetryableImportError(err) {
+				continue
+			}
+
+			for _, item := range batch {
+				item.result.Status = ImportStatusFailed
+				item.result.Error = err
+			}
+
+			return errImportBatchFailed
+		}
+
+		retries := make([]*importItem, 0)
+		for idx, item := range batch {
+			code := 0
+			if idx < len(results) {
+				code = results[idx].Result
+				item.result.MsgSeq = results[idx].MsgSeq
+			}
+
+			if code == 0 {
+				item.result.Status = ImportStatusSucceeded
+				if err = cp.Mark(item.key); err != nil {
+					return err
+				}
+				continue
+			}
+
+			msg, permanent := importPermanentResults[code]
+			if !permanent && attempt < i.opt.MaxRetries {
+				retries = append(retries, item)
+				continue
+			}
+
+			if msg == "" {
+				msg = "import group message failed"
+			}
+
+			failed = true
+			item.result.Status = ImportStatusFailed
+			item.result.Error = core.NewError(code, msg)
+		}
+
+		batch = retries
+	}
+
+	if failed {
+		return errImportBatchFailed
+	}
+
+	return nil
+}
+
+// ImportGroup 立即按顺序导入单个群组的消息并返回逐条导入结果，重复消息的结果排在最后
+// 适用于逐个群组拉取并导入的流式场景，导入完成后不保留消息；不同群组可在多个协程中并发调用，
+// 所有调用共用频率限制与断点记录，使用完毕后需调用 Close 关闭断点文件。请勿与 Run 同时使用同一断点文件。
+// 单条消息导入失败记录在导入结果中；仅在断点文件读写失败或上下文结束时返回错误。
+func (i *Importer) ImportGroup(ctx context.Context, groupId string, messages ...*Message) (results []*ImportResult, err error) {
+	cp, limit, err := i.stream()
+	if err != nil {
+		return
+	}
+
+	var (
+		keys    = make(map[string]struct{}, len(messages))
+		items   = make([]*importItem, 0, len(messages))
+		repeats = make([]*importItem, 0)
+	)
+
+	for _, message := range messages {
+		item := newImportItem(groupId, message)
+
+		if _, ok := keys[item.key]; ok {
+			item.result.Status = ImportStatusDuplicated
+			repeats = append(repeats, item)
+			continue
+		}
+
+		keys[item.key] = struct{}{}
+		items = append(items, item)
+	}
+
+	err = i.importGroup(ctx, cp, limit, groupId, items)
+
+	results = make([]*ImportResult, 0, len(messages))
+	for _, item := range append(items, repeats...) {
+		results = append(results, item.result)
+	}
+
+	return
+}
+
+// Close 关闭流式导入（ImportGroup）使用的断点文件与频率限制器
+func (i *Importer) Close() (err error) {
+	i.smu.Lock()
+	defer i.smu.Unlock()
+
+	if i.slimit != nil {
+		i.slimit.Stop()
+		i.slimit = nil
+	}
+
+	if i.scp != nil {
+		err = i.scp.Close()
+		i.scp = nil
+	}
+
+	return
+}
+
+// stream 获取流式导入共用的断点记录与频率限制器，首次调用时打开
+func (i *Importer) stream() (*checkpoint.Checkpoint, *limiter.Limiter, error) {
+	i.smu.Lock()
+	defer i.smu.Unlock()
+
+	if i.scp == nil {
+		cp, err := checkpoint.Open(i.opt.CheckpointFile)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		i.scp = cp
+		i.slimit = limiter.New(i.opt.RateLimit)
+	}
+
+	return i.scp, i.slimit, nil
+}
+
+// FailedResults 获取导入失败的结果
+func (r *ImportReport) FailedResults() (results []*ImportResult) {
+	for _, result := range r.Results {
+		if result.Status == ImportStatusFailed {
+			results = append(results, result)
+		}
+	}
+
+	return
+}
+
+// isRetryableImportError 是否为可重试的错误，参数错误不重试
+func isRetryableImportError(err error) bool {
+	if e, ok := err.(core.Error); ok {
+		return e.Code() != enum.InvalidParamsCode
+	}
+
+	return true
+}
+
+func newImportItem(groupId string, message *Message) *importItem {
+	item := &importItem{
+		message: message,
+		result: &ImportResult{
+			GroupId:    groupId,
+			FromUserId: message.GetSender(),
+			SendTime:   message.GetSendTime(),
+			MsgRandom:  message.GetRandom(),
+		},
+	}
+	item.key = fmt.Sprintf("%s|%s|%d|%s", groupId, item.result.FromUserId, item.result.SendTime, bodyDigest(message.GetBody()))
+
+	return item
+}
+
+// abortImportItems 将未导入的消息记为失败
+func abortImportItems(items []*importItem, err error) {
+	for _, item := range items {
+		if item.result.Status == "" {
+			item.result.Status = ImportStatusFailed
+			item.result.Error = err
+		}
+	}
+}
+
+// bodyDigest 消息内容的摘要
+func bodyDigest(body interface{}) string {
+	data, _ := json.Marshal(body)
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:8])
+}
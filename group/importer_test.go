@@ -0,0 +1,165 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/19 10:30
+ * @Desc: 群消息批量导入测试
+ */
+
+package group
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/default-yarns/tencent-im/internal/core"
+	"github.com/default-yarns/tencent-im/internal/types"
+)
+
+type stubImportAPI struct {
+	API
+	mu       sync.Mutex
+	imported []string
+	results  map[string]int
+	err      error
+}
+
+func (s *stubImportAPI) ImportMessages(groupId string, messages ...*Message) ([]ImportMessagesResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	results := make([]ImportMessagesResult, 0, len(messages))
+	for _, message := range messages {
+		text := message.GetBody()[0].MsgContent.(types.MsgTextContent).Text
+		code := s.results[text]
+		if code == 0 {
+			s.imported = append(s.imported, text)
+		}
+		results = append(results, ImportMessagesResult{Result: code})
+	}
+
+	return results, nil
+}
+
+func newImportMessage(sendTime int64, text string) *Message {
+	message := NewMessage()
+	message.SetSender("a")
+	message.SetSendTime(sendTime)
+	message.SetContent(types.MsgTextContent{Text: text})
+
+	return message
+}
+
+func TestImporter_AbortGroupAfterFailure(t *testing.T) {
+	api := &stubImportAPI{results: map[string]int{"m3": 80001}}
+	importer := NewImporter(api, nil)
+
+	for i := 1; i <= 10; i++ {
+		importer.Add("g1", newImportMessage(int64(i), fmt.Sprintf("m%d", i)))
+	}
+	importer.Add("g2", newImportMessage(1, "other"))
+
+	report, err := importer.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 第一批中 m3 永久失败，同批其余消息已导入，第二批不再导入
+	if report.Succeeded != 7 || report.Failed != 4 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	for _, result := range report.FailedResults() {
+		if result.SendTime > 7 && result.Error != ErrImportAborted {
+			t.Fatalf("message at %d should be aborted, got %v", result.SendTime, result.Error)
+		}
+	}
+
+	for _, text := range api.imported {
+		if text == "m8" || text == "m9" || text == "m10" {
+			t.Fatalf("message %s of the aborted group was imported", text)
+		}
+	}
+}
+
+func TestImporter_DedupWithoutRandom(t *testing.T) {
+	api := &stubImportAPI{}
+	importer := NewImporter(api, nil)
+	importer.Add("g1", newImportMessage(1, "hello"), newImportMessage(1, "hello"), newImportMessage(1, "world"))
+
+	report, err := importer.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Succeeded != 2 || report.Duplicated != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+}
+
+func TestImporter_CancelRetry(t *testing.T) {
+	api := &stubImportAPI{err: core.NewError(70001, "busy")}
+	importer := NewImporter(api, &ImporterOptions{RetryInterval: time.Hour})
+	importer.Add("g1", newImportMessage(1, "hello"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	report, err := importer.RunContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+
+	if time.Since(start) > time.Second {
+		t.Fatal("retry wait was not cancelled")
+	}
+
+	if report.Failed != 1 || report.Results[0].Error != context.DeadlineExceeded {
+		t.Fatalf("unexpected report: %+v", report.Results[0])
+	}
+}
+
+func TestImporter_ImportGroup(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "import.checkpoint")
+
+	api := &stubImportAPI{}
+	importer := NewImporter(api, &ImporterOptions{CheckpointFile: file})
+	results, err := importer.ImportGroup(context.Background(), "g1", newImportMessage(2, "second"), newImportMessage(1, "first"), newImportMessage(1, "first"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 3 || results[2].Status != ImportStatusDuplicated {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+
+	if len(api.imported) != 2 || api.imported[0] != "first" || api.imported[1] != "second" {
+		t.Fatalf("unexpected import order: %v", api.imported)
+	}
+
+	if err = importer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 使用同一断点文件再次导入时跳过已导入的消息
+	api = &stubImportAPI{}
+	importer = NewImporter(api, &ImporterOptions{CheckpointFile: file})
+	defer importer.Close()
+
+	if results, err = importer.ImportGroup(context.Background(), "g1", newImportMessage(1, "first"), newImportMessage(3, "third")); err != nil {
+		t.Fatal(err)
+	}
+
+	if results[0].Status != ImportStatusSkipped || results[1].Status != ImportStatusSucceeded || len(api.imported) != 1 {
+		t.Fatalf("unexpected results: %+v %+v", results[0], results[1])
+	}
+}
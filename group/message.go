@@ -57,6 +57,7 @@ type Message struct {
 	sendControls     map[string]bool   // 发送消息控制
 	callbackControls map[string]bool   // 禁用回调
 	atMembers        map[string]bool   // @用户
+	supportExtension bool              // 支持消息扩展
 }
 
 func NewMessage() *Message {
@@ -154,6 +155,20 @@ func (m *Message) SetNoLastMsg() {
 	m.sendControls["NoLastMsg"] = true
 }
 
+// SetSupportMessageExtension 设置该条消息支持消息扩展
+func (m *Message) SetSupportMessageExtension() {
+	m.supportExtension = true
+}
+
+// GetSupportMessageExtension 获取消息扩展开关（1表示支持；0表示不支持）
+func (m *Message) GetSupportMessageExtension() int {
+	if m.supportExtension {
+		return 1
+	}
+
+	return 0
+}
+
 // GetSendMsgControl 获取消息发送控制选项
 func (m *Message) GetSendMsgControl() (controls []string) {
 	if m.sendControls != nil {
@@ -194,6 +209,11 @@ func (m *Message) GetTimestamp() int64 {
 	return m.timestamp
 }
 
+// GetSerialNo 获取消息序列号（仅拉取的历史消息有值）
+func (m *Message) GetSerialNo() int {
+	return m.seq
+}
+
 // 检测发送错误
 func (m *Message) checkSendError() (err error) {
 	if err = m.CheckBodyArgError(); err != nil {
@@ -316,19 +316,67 @@ type (
 		ShuttedUntil int64  `json:"ShuttedUntil"`   // 禁言到的时间（使用 UTC 时间，即世界协调时间）
 	}
 
+	// 群成员封禁（请求）
+	banMembersReq struct {
+		GroupId     string   `json:"GroupId"`               // （必填）需要封禁成员的群组 ID
+		UserIds     []string `json:"Members_Account"`       // （必填）需要封禁的用户帐号，最多支持20个帐号
+		Duration    int64    `json:"Duration"`              // （必填）封禁时长，单位为秒
+		Description string   `json:"Description,omitempty"` // （选填）封禁信息
+	}
+
+	// 群成员解封（请求）
+	unbanMembersReq struct {
+		GroupId string   `json:"GroupId"`         // （必填）需要解封成员的群组 ID
+		UserIds []string `json:"Members_Account"` // （必填）需要解封的用户帐号，最多支持20个帐号
+	}
+
+	// 获取封禁群成员列表（请求）
+	fetchBannedMembersReq struct {
+		GroupId string `json:"GroupId"`          // （必填）需要获取封禁成员列表的群组 ID
+		Limit   int    `json:"Limit,omitempty"`  // （选填）单次请求拉取的数量，最大值为100
+		Offset  int    `json:"Offset,omitempty"` // （选填）拉取的偏移量，第一次填0，以后填上一次返回的 NextOffset
+	}
+
+	// 获取封禁群成员列表（响应）
+	fetchBannedMembersResp struct {
+		types.ActionBaseResp
+		NextOffset int             `json:"NextOffset"`        // 下一次拉取的偏移量，为0时表示已拉取完毕
+		List       []*BannedMember `json:"BannedAccountList"` // 封禁成员列表
+	}
+
+	// BannedMember 封禁成员信息
+	BannedMember struct {
+		UserId      string `json:"Member_Account"` // 用户ID
+		BannedUntil int64  `json:"BannedUntil"`    // 封禁到的时间（使用 UTC 时间，即世界协调时间）
+	}
+
+	// FetchBannedMembersRet 获取封禁群成员列表（返回）
+	FetchBannedMembersRet struct {
+		Next    int             // 分页拉取的标志
+		HasMore bool            // 是否还有更多数据
+		List    []*BannedMember // 封禁成员列表
+	}
+
+	// PullBannedMembersArg 续拉取封禁群成员列表（参数）
+	PullBannedMembersArg struct {
+		GroupId string // （必填）需要获取封禁成员列表的群组 ID
+		Limit   int    // （选填）单次请求拉取的数量，最大值为100
+	}
+
 	// 在群组中发送普通消息（请求）
 	sendMessageReq struct {
-		GroupId               string                 `json:"GroupId"`                         // （必填）向哪个群组发送消息
-		Random                uint32                 `json:"Random"`                          // （必填）无符号32位整数
-		MsgPriority           string                 `json:"MsgPriority,omitempty"`           // （选填）消息的优先级
-		FromUserId            string                 `json:"From_Account,omitempty"`          // （选填）消息来源帐号
-		MsgBody               []*types.MsgBody       `json:"MsgBody"`                         // （必填）消息体
-		OnlineOnlyFlag        int                    `json:"MsgOnlineOnlyFlag,omitempty"`     // （选填）1表示消息仅发送在线成员，默认0表示发送所有成员，AVChatRoom(直播群)不支持该参数
-		SendMsgControl        []string               `json:"SendMsgControl,omitempty"`        // （选填）消息发送权限，NoLastMsg 只对单条消息有效，表示不更新最近联系人会话；NoUnread 不计未读，只对单条消息有效。（如果该消息 MsgOnlineOnlyFlag 设置为1，则不允许使用该字段。）
-		ForbidCallbackControl []string               `json:"ForbidCallbackControl,omitempty"` // （选填）消息回调禁止开关，只对单条消息有效
-		OfflinePushInfo       *types.OfflinePushInfo `json:"OfflinePushInfo,omitempty"`       // （选填）离线推送信息配置
-		CloudCustomData       string                 `json:"CloudCustomData,omitempty"`       // （选填）消息自定义数据（云端保存，会发送到对端，程序卸载重装后还能拉取到）
-		GroupAtInfo           []atInfo               `json:"GroupAtInfo,omitempty"`           // （选填）@某个用户或者所有人
+		GroupId               string                 `json:"GroupId"`                           // （必填）向哪个群组发送消息
+		Random                uint32                 `json:"Random"`                            // （必填）无符号32位整数
+		MsgPriority           string                 `json:"MsgPriority,omitempty"`             // （选填）消息的优先级
+		FromUserId            string                 `json:"From_Account,omitempty"`            // （选填）消息来源帐号
+		MsgBody               []*types.MsgBody       `json:"MsgBody"`                           // （必填）消息体
+		OnlineOnlyFlag        int                    `json:"MsgOnlineOnlyFlag,omitempty"`       // （选填）1表示消息仅发送在线成员，默认0表示发送所有成员，AVChatRoom(直播群)不支持该参数
+		SendMsgControl        []string               `json:"SendMsgControl,omitempty"`          // （选填）消息发送权限，NoLastMsg 只对单条消息有效，表示不更新最近联系人会话；NoUnread 不计未读，只对单条消息有效。（如果该消息 MsgOnlineOnlyFlag 设置为1，则不允许使用该字段。）
+		ForbidCallbackControl []string               `json:"ForbidCallbackControl,omitempty"`   // （选填）消息回调禁止开关，只对单条消息有效
+		OfflinePushInfo       *types.OfflinePushInfo `json:"OfflinePushInfo,omitempty"`         // （选填）离线推送信息配置
+		CloudCustomData       string                 `json:"CloudCustomData,omitempty"`         // （选填）消息自定义数据（云端保存，会发送到对端，程序卸载重装后还能拉取到）
+		GroupAtInfo           []atInfo               `json:"GroupAtInfo,omitempty"`             // （选填）@某个用户或者所有人
+		SupportExtension      int                    `json:"SupportMessageExtension,omitempty"` // （选填）该条消息是否支持消息扩展，0为不支持，1为支持
 	}
 
 	// 在群组中发送普通消息（响应）
@@ -384,6 +432,29 @@ type (
 		RetCode int `json:"RetCode"` // 单个消息的被撤回结果：0表示成功；其它表示失败
 	}
 
+	// 删除群消息（请求）
+	deleteMessagesReq struct {
+		GroupId    string       `json:"GroupId"`    // （必填）操作的群ID
+		MsgSeqList []msgSeqItem `json:"MsgSeqList"` // （必填）被删除的消息 seq 列表
+	}
+
+	// 删除群消息（响应）
+	deleteMessagesResp struct {
+		types.ActionBaseResp
+		Results []deleteMessageResult `json:"Results"` // 删除结果列表
+	}
+
+	// 删除群消息结果
+	deleteMessageResult struct {
+		MsgSeq  int `json:"MsgSeq"`  // 单个被删除消息的 seq
+		RetCode int `json:"RetCode"` // 单个消息的删除结果：0表示成功；其它表示失败
+	}
+
+	// 清空群历史消息（请求）
+	clearMessagesReq struct {
+		GroupId string `json:"GroupId"` // （必填）操作的群ID
+	}
+
 	// 导入群基础资料（请求）
 	importGroupReq struct {
 		OwnerUserId     string            `json:"Owner_Account,omitempty"`   // （选填）群主 ID（需是 已导入 的账号）。填写后自动添加到群成员中；如果不填，群没有群主
@@ -505,4 +576,74 @@ type (
 		types.ActionBaseResp
 		OnlineMemberNum int `json:"OnlineMemberNum"` // 该群组的在线人数
 	}
+
+	// 获取直播群在线成员列表（请求）
+	fetchOnlineMembersReq struct {
+		GroupId   string `json:"GroupId"`             // （必填）操作的群ID
+		Timestamp int64  `json:"Timestamp,omitempty"` // （选填）拉取的时间戳，第一次填0，以后填上一次返回的 NextTimestamp
+	}
+
+	// 获取直播群在线成员列表（响应）
+	fetchOnlineMembersResp struct {
+		types.ActionBaseResp
+		NextTimestamp int64        `json:"NextTimestamp"` // 下一次拉取的时间戳，为0时表示已拉取完毕
+		MemberList    []memberItem `json:"MemberList"`    // 在线成员列表
+	}
+
+	// FetchOnlineMembersRet 获取直播群在线成员列表（返回）
+	FetchOnlineMembersRet struct {
+		Next    int64     // 分页拉取的标志
+		HasMore bool      // 是否还有更多数据
+		List    []*Member // 在线成员列表
+	}
+
+	// 直播群广播消息（请求）
+	sendBroadcastMessageReq struct {
+		FromUserId      string           `json:"From_Account,omitempty"`    // （选填）消息来源帐号
+		Random          uint32           `json:"Random"`                    // （必填）无符号32位整数
+		MsgBody         []*types.MsgBody `json:"MsgBody"`                   // （必填）消息体
+		CloudCustomData string           `json:"CloudCustomData,omitempty"` // （选填）消息自定义数据
+	}
+
+	// 设置群消息扩展（请求）
+	setMessageExtensionsReq struct {
+		GroupId       string              `json:"GroupId"`                 // （必填）操作的群ID
+		MsgSeq        int                 `json:"MsgSeq"`                  // （必填）消息的序列号
+		OperateType   int                 `json:"OperateType"`             // （必填）操作类型：1表示设置；2表示删除；3表示清空
+		ExtensionList []*MessageExtension `json:"ExtensionList,omitempty"` // （选填）扩展项列表，清空时不需要填写
+	}
+
+	// 设置群消息扩展（响应）
+	setMessageExtensionsResp struct {
+		types.ActionBaseResp
+		Results []MessageExtensionResult `json:"ExtensionList"` // 扩展项操作结果
+	}
+
+	// 获取群消息扩展（请求）
+	fetchMessageExtensionsReq struct {
+		GroupId  string `json:"GroupId"`            // （必填）操作的群ID
+		MsgSeq   int    `json:"MsgSeq"`             // （必填）消息的序列号
+		StartSeq int    `json:"StartSeq,omitempty"` // （选填）拉取的起始版本号，第一次拉取时不填
+	}
+
+	// 获取群消息扩展（响应）
+	fetchMessageExtensionsResp struct {
+		types.ActionBaseResp
+		Complete      int                 `json:"Complete"`      // 是否全部拉取，0表示未全部拉取，需要续拉，1表示已全部拉取
+		LatestSeq     int                 `json:"LatestSeq"`     // 当前最新的扩展版本号
+		ClearSeq      int                 `json:"ClearSeq"`      // 最近一次清空扩展时的版本号
+		ExtensionList []*MessageExtension `json:"ExtensionList"` // 扩展项列表
+	}
+
+	// FetchMessageExtensionsRet 获取群消息扩展（返回）
+	FetchMessageExtensionsRet struct {
+		LatestSeq int                 // 当前最新的扩展版本号
+		ClearSeq  int                 // 最近一次清空扩展时的版本号
+		NextSeq   int                 // 下一次拉取的起始版本号
+		HasMore   bool                // 是否还有更多数据
+		List      []*MessageExtension // 扩展项列表
+	}
+
+	MessageExtension       = types.MsgExtension
+	MessageExtensionResult = types.MsgExtensionResult
 )
@@ -17,12 +17,14 @@ import (
 	"github.com/default-yarns/tencent-im/internal/core"
 	"github.com/default-yarns/tencent-im/internal/sign"
 	"github.com/default-yarns/tencent-im/mute"
+	"github.com/default-yarns/tencent-im/official"
 	"github.com/default-yarns/tencent-im/operation"
 	"github.com/default-yarns/tencent-im/private"
 	"github.com/default-yarns/tencent-im/profile"
 	"github.com/default-yarns/tencent-im/push"
 	"github.com/default-yarns/tencent-im/recentcontact"
 	"github.com/default-yarns/tencent-im/sns"
+	"github.com/default-yarns/tencent-im/unread"
 )
 
 type Error = core.Error
@@ -49,6 +51,10 @@ type (
 		Operation() operation.API
 		// RecentContact 获取最近联系人接口
 		RecentContact() recentcontact.API
+		// Unread 获取未读消息汇总接口
+		Unread() unread.API
+		// Official 获取公众号管理接口
+		Official() official.API
 		// Callback 获取回调接口
 		Callback() callback.Callback
 	}
@@ -105,6 +111,14 @@ type (
 			once     sync.Once
 			instance recentcontact.API
 		}
+		unread struct {
+			once     sync.Once
+			instance unread.API
+		}
+		official struct {
+			once     sync.Once
+			instance official.API
+		}
 		callback struct {
 			once     sync.Once
 			instance callback.Callback
@@ -204,6 +218,22 @@ func (i *im) RecentContact() recentcontact.API {
 	return i.recentcontact.instance
 }
 
+// Unread 获取未读消息汇总接口
+func (i *im) Unread() unread.API {
+	i.unread.once.Do(func() {
+		i.unread.instance = unread.NewAPI(i.client)
+	})
+	return i.unread.instance
+}
+
+// Official 获取公众号管理接口
+func (i *im) Official() official.API {
+	i.official.once.Do(func() {
+		i.official.instance = official.NewAPI(i.client)
+	})
+	return i.official.instance
+}
+
 // Callback 获取回调接口
 func (i *im) Callback() callback.Callback {
 	i.callback.once.Do(func() {
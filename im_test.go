@@ -8,15 +8,21 @@
 package im_test
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
+	"os"
 	"strconv"
 	"testing"
 	"time"
 
 	"github.com/default-yarns/tencent-im"
 	"github.com/default-yarns/tencent-im/account"
+	"github.com/default-yarns/tencent-im/backup"
+	"github.com/default-yarns/tencent-im/callback"
 	"github.com/default-yarns/tencent-im/group"
+	"github.com/default-yarns/tencent-im/migrate"
+	"github.com/default-yarns/tencent-im/official"
 	"github.com/default-yarns/tencent-im/operation"
 	"github.com/default-yarns/tencent-im/private"
 	"github.com/default-yarns/tencent-im/profile"
@@ -198,6 +204,67 @@ func TestIm_Account_QueryAccountsOnlineStatus(t *testing.T) {
 	t.Log(resp.Errors)
 }
 
+// 设置用户自定义状态
+func TestIm_Account_SetCustomStatus(t *testing.T) {
+	if err := NewIM().Account().SetCustomStatus(test1, "busy"); err != nil {
+		handleError(t, "account.SetCustomStatus", err)
+	}
+
+	statuses, err := NewIM().Account().GetCustomStatuses(test1, test2)
+	if err != nil {
+		handleError(t, "account.GetCustomStatuses", err)
+	}
+
+	t.Log(statuses)
+}
+
+// 订阅用户状态
+func TestIm_Account_SubscribeStatus(t *testing.T) {
+	failUserIds, err := NewIM().Account().SubscribeStatus(test1, test2, assistant)
+	if err != nil {
+		handleError(t, "account.SubscribeStatus", err)
+	}
+
+	t.Log(failUserIds)
+}
+
+// 在线状态跟踪
+func TestIm_Account_PresenceTracker(t *testing.T) {
+	tracker := account.NewPresenceTracker(NewIM().Account())
+	tracker.OnChange(func(change *account.PresenceChange) {
+		t.Log(change.UserId, change.Previous, change.Current)
+	})
+	tracker.Listen(NewIM().Callback())
+
+	if err := tracker.Track(test1, test2); err != nil {
+		handleError(t, "account.PresenceTracker.Track", err)
+	}
+
+	t.Log(tracker.GetAllStatus())
+}
+
+// 创建机器人账号
+func TestIm_Account_CreateRobot(t *testing.T) {
+	if err := NewIM().Account().CreateRobot(&account.Robot{
+		UserId:   "@RBT#assistant",
+		Nickname: "小助手",
+	}); err != nil {
+		handleError(t, "account.CreateRobot", err)
+	}
+
+	t.Log("Success")
+}
+
+// 获取所有机器人账号
+func TestIm_Account_FetchRobots(t *testing.T) {
+	userIds, err := NewIM().Account().FetchRobots()
+	if err != nil {
+		handleError(t, "account.FetchRobots", err)
+	}
+
+	t.Log(userIds)
+}
+
 // 全员推送
 func TestIm_Push_PushMessage(t *testing.T) {
 	message := push.NewMessage()
@@ -403,6 +470,55 @@ func TestIm_Operation_GetIpList(t *testing.T) {
 	t.Log("Success")
 }
 
+// 开启回调来源IP白名单
+func TestIm_Callback_EnableIPAllowlist(t *testing.T) {
+	tim := NewIM()
+	if err := tim.Callback().EnableIPAllowlist(tim.Operation()); err != nil {
+		handleError(t, "callback.EnableIPAllowlist", err)
+	}
+	defer tim.Callback().Shutdown(context.Background())
+
+	t.Log("Success")
+}
+
+// 回调审批策略
+func TestIm_Callback_Policy(t *testing.T) {
+	policy := callback.NewPolicy(NewIM(), &callback.PolicyOptions{
+		MaxFriends:     500,
+		BlockedUserIds: []string{test1},
+		FailClosed:     true,
+	})
+
+	resp := policy.EvaluateApplyJoinGroup(&callback.BeforeApplyJoinGroup{
+		GroupId:         "test_group",
+		RequestorUserId: assistant,
+	})
+
+	t.Log(resp.ErrorCode, resp.ErrorInfo)
+}
+
+// 回调归档与重放
+func TestIm_Callback_Replay(t *testing.T) {
+	tim := NewIM()
+	if err := tim.Callback().EnableArchive(&callback.ArchiveOptions{Path: os.TempDir() + "/callback-archive.jsonl"}); err != nil {
+		handleError(t, "callback.EnableArchive", err)
+	}
+	defer tim.Callback().Shutdown(context.Background())
+
+	ret, err := tim.Callback().Replay(context.Background(), &callback.ReplayOptions{
+		Start: time.Now().Add(-time.Hour),
+	})
+	if err != nil {
+		handleError(t, "callback.Replay", err)
+	}
+
+	if ret.Total != ret.Succeeded+ret.Failed {
+		t.Fatalf("unexpected replay result: %+v", ret)
+	}
+
+	t.Log(ret.Total, ret.Succeeded, ret.Failed)
+}
+
 // 设置全局禁言
 func TestIm_Mute_SetNoSpeaking(t *testing.T) {
 	var privateMuteTime uint = 400
@@ -916,6 +1032,34 @@ func TestIm_Private_ImportMessage(t *testing.T) {
 	t.Log("Success")
 }
 
+// 批量导入单聊消息
+func TestIm_Private_Importer(t *testing.T) {
+	importer := private.NewImporter(NewIM().Private(), &private.ImporterOptions{
+		Concurrency:    5,
+		CheckpointFile: os.TempDir() + "/private_import.checkpoint",
+	})
+
+	now := time.Now().Unix()
+	for i := 0; i < 10; i++ {
+		message := private.NewMessage()
+		message.SetSender(assistant)
+		message.SetReceivers(test1)
+		message.SetTimestamp(now - int64(i))
+		message.SetSyncOtherMachine(private.SyncOtherMachineYes)
+		message.SetContent(private.MsgTextContent{
+			Text: fmt.Sprintf("Hello world %d", i),
+		})
+		importer.Add(message)
+	}
+
+	report, err := importer.Run()
+	if err != nil {
+		handleError(t, "private.Importer.Run", err)
+	}
+
+	t.Log(report.Total, report.Succeeded, report.Failed, report.Skipped, report.Duplicated)
+}
+
 // 查询单聊消息
 func TestIm_Private_FetchMessages(t *testing.T) {
 	var (
@@ -1006,6 +1150,47 @@ func TestIm_Private_GetUnreadMessageNum(t *testing.T) {
 	t.Log(ret.Errors)
 }
 
+// 单向删除多条单聊消息
+func TestIm_Private_DeleteMessages(t *testing.T) {
+	results, err := NewIM().Private().DeleteMessages(test1, test2, "1_1_1", "2_2_2")
+	if err != nil {
+		handleError(t, "private.DeleteMessages", err)
+	}
+
+	t.Log(results)
+}
+
+// 单向抹除单聊会话
+func TestIm_Private_EraseSession(t *testing.T) {
+	if err := NewIM().Private().EraseSession(test1, test2); err != nil {
+		handleError(t, "private.EraseSession", err)
+	}
+}
+
+// 设置单聊消息扩展
+func TestIm_Private_SetMessageExtensions(t *testing.T) {
+	results, err := NewIM().Private().SetMessageExtensions(test1, test2, "1_1_1", &private.MessageExtension{
+		Key:   "like",
+		Value: "1",
+	})
+	if err != nil {
+		handleError(t, "private.SetMessageExtensions", err)
+	}
+
+	t.Log(results)
+}
+
+// 续拉取单聊消息扩展
+func TestIm_Private_PullMessageExtensions(t *testing.T) {
+	err := NewIM().Private().PullMessageExtensions(test1, test2, "1_1_1", func(ret *private.FetchMessageExtensionsRet) {
+		t.Log(ret.LatestSeq)
+		t.Log(ret.List)
+	})
+	if err != nil {
+		handleError(t, "private.PullMessageExtensions", err)
+	}
+}
+
 // 创建群组
 func TestIm_Group_CreateGroup(t *testing.T) {
 	g := group.NewGroup()
@@ -1435,6 +1620,34 @@ func TestIm_Group_ImportMessages(t *testing.T) {
 	t.Log(results)
 }
 
+// 批量导入群消息
+func TestIm_Group_Importer(t *testing.T) {
+	importer := group.NewImporter(NewIM().Group(), &group.ImporterOptions{
+		Concurrency:    2,
+		CheckpointFile: os.TempDir() + "/group_import.checkpoint",
+	})
+
+	now := time.Now().Unix()
+	for _, groupId := range []string{"test_group1", "test_group2"} {
+		for i := 0; i < 20; i++ {
+			message := group.NewMessage()
+			message.SetSender(test1)
+			message.SetSendTime(now - int64(i))
+			message.SetContent(private.MsgTextContent{
+				Text: fmt.Sprintf("Hello world %d", i),
+			})
+			importer.Add(groupId, message)
+		}
+	}
+
+	report, err := importer.Run()
+	if err != nil {
+		handleError(t, "group.Importer.Run", err)
+	}
+
+	t.Log(report.Total, report.Succeeded, report.Failed, report.Skipped, report.Duplicated)
+}
+
 // 导入多个群成员
 func TestIm_Group_ImportMembers(t *testing.T) {
 	members := make([]*group.Member, 0)
@@ -1475,6 +1688,92 @@ func TestIm_Group_PullMessages(t *testing.T) {
 	}
 }
 
+// 删除多条群消息
+func TestIm_Group_DeleteMessages(t *testing.T) {
+	results, err := NewIM().Group().DeleteMessages("test_group2", 1, 2, 3)
+	if err != nil {
+		handleError(t, "group.DeleteMessages", err)
+	}
+
+	t.Log(results)
+}
+
+// 清空群历史消息
+func TestIm_Group_ClearMessages(t *testing.T) {
+	if err := NewIM().Group().ClearMessages("test_group2"); err != nil {
+		handleError(t, "group.ClearMessages", err)
+	}
+}
+
+// 群成员封禁
+func TestIm_Group_BanMembers(t *testing.T) {
+	err := NewIM().Group().BanMembers("test_group2", []string{test1, test2}, 3600, "spam")
+	if err != nil {
+		handleError(t, "group.BanMembers", err)
+	}
+}
+
+// 续拉取封禁群成员列表
+func TestIm_Group_PullBannedMembers(t *testing.T) {
+	err := NewIM().Group().PullBannedMembers(&group.PullBannedMembersArg{
+		GroupId: "test_group2",
+		Limit:   10,
+	}, func(ret *group.FetchBannedMembersRet) {
+		t.Log(ret.List)
+	})
+	if err != nil {
+		handleError(t, "group.PullBannedMembers", err)
+	}
+}
+
+// 续拉取直播群在线成员列表
+func TestIm_Group_PullOnlineMembers(t *testing.T) {
+	err := NewIM().Group().PullOnlineMembers("test_group2", func(ret *group.FetchOnlineMembersRet) {
+		for _, member := range ret.List {
+			t.Log(member.GetUserId(), member.GetAllCustomData())
+		}
+	})
+	if err != nil {
+		handleError(t, "group.PullOnlineMembers", err)
+	}
+}
+
+// 直播群广播消息
+func TestIm_Group_SendBroadcastMessage(t *testing.T) {
+	message := group.NewMessage()
+	message.SetSender(assistant)
+	message.SetContent(private.MsgTextContent{
+		Text: "broadcast message",
+	})
+
+	if err := NewIM().Group().SendBroadcastMessage(message); err != nil {
+		handleError(t, "group.SendBroadcastMessage", err)
+	}
+}
+
+// 设置群消息扩展
+func TestIm_Group_SetMessageExtensions(t *testing.T) {
+	results, err := NewIM().Group().SetMessageExtensions("test_group2", 5, &group.MessageExtension{
+		Key:   "like",
+		Value: "1",
+	})
+	if err != nil {
+		handleError(t, "group.SetMessageExtensions", err)
+	}
+
+	t.Log(results)
+}
+
+// 获取群消息扩展
+func TestIm_Group_FetchMessageExtensions(t *testing.T) {
+	ret, err := NewIM().Group().FetchMessageExtensions("test_group2", 5)
+	if err != nil {
+		handleError(t, "group.FetchMessageExtensions", err)
+	}
+
+	t.Log(ret)
+}
+
 // 拉取会话列表
 func TestIm_RecentContact_FetchSessions(t *testing.T) {
 	ret, err := NewIM().RecentContact().FetchSessions(&recentcontact.FetchSessionsArg{
@@ -1509,3 +1808,157 @@ func TestIm_RecentContact_DeleteSession(t *testing.T) {
 	}
 	t.Log("Success")
 }
+
+// 创建会话分组
+func TestIm_RecentContact_CreateSessionGroup(t *testing.T) {
+	groupId, err := NewIM().RecentContact().CreateSessionGroup(assistant, "friends",
+		recentcontact.NewC2CContact(test1),
+		recentcontact.NewG2CContact("test_group2"),
+	)
+	if err != nil {
+		handleError(t, "recentcontact.CreateSessionGroup", err)
+	}
+
+	t.Log(groupId)
+}
+
+// 续拉取会话分组
+func TestIm_RecentContact_PullSessionGroups(t *testing.T) {
+	err := NewIM().RecentContact().PullSessionGroups(assistant, func(ret *recentcontact.FetchSessionGroupsRet) {
+		t.Log(ret.Groups)
+		for _, item := range ret.Contacts {
+			t.Log(item.UserId, item.GroupId, item.ContactGroupIds, item.HasMark(recentcontact.SessionMarkStar))
+		}
+	})
+	if err != nil {
+		handleError(t, "recentcontact.PullSessionGroups", err)
+	}
+}
+
+// 设置会话标记
+func TestIm_RecentContact_MarkSessions(t *testing.T) {
+	results, err := NewIM().RecentContact().MarkSessions(assistant, &recentcontact.SessionMarkArg{
+		Contact:    recentcontact.NewC2CContact(test1),
+		SetMarks:   []recentcontact.SessionMark{recentcontact.SessionMarkStar},
+		UnsetMarks: []recentcontact.SessionMark{recentcontact.SessionMarkFold},
+	})
+	if err != nil {
+		handleError(t, "recentcontact.MarkSessions", err)
+	}
+
+	t.Log(results)
+}
+
+// 获取用户未读消息汇总
+func TestIm_Unread_GetSummary(t *testing.T) {
+	ret, err := NewIM().Unread().GetSummary(assistant)
+	if err != nil {
+		handleError(t, "unread.GetSummary", err)
+	}
+
+	t.Log(ret.Total, ret.C2CTotal, ret.GroupTotal)
+	for _, item := range ret.Sessions {
+		t.Log(item.Type, item.UserId, item.GroupId, item.UnreadMsgNum)
+	}
+}
+
+// 标记用户的所有会话已读
+func TestIm_Unread_MarkAllRead(t *testing.T) {
+	if err := NewIM().Unread().MarkAllRead(assistant); err != nil {
+		handleError(t, "unread.MarkAllRead", err)
+	}
+}
+
+// 创建公众号
+func TestIm_Official_CreateAccount(t *testing.T) {
+	account := official.NewAccount("test_official")
+	account.SetOwner(assistant)
+	account.SetName("测试公众号")
+	account.SetIntroduction("这是一个测试公众号")
+
+	accountId, err := NewIM().Official().CreateAccount(account)
+	if err != nil {
+		handleError(t, "official.CreateAccount", err)
+	}
+
+	t.Log(accountId)
+}
+
+// 获取公众号资料
+func TestIm_Official_GetAccount(t *testing.T) {
+	account, err := NewIM().Official().GetAccount("test_official")
+	if err != nil {
+		handleError(t, "official.GetAccount", err)
+	}
+
+	t.Log(account.GetName(), account.GetOwner(), account.GetSubscriberNum())
+}
+
+// 续拉取公众号订阅者列表
+func TestIm_Official_PullSubscribers(t *testing.T) {
+	err := NewIM().Official().PullSubscribers(&official.PullSubscribersArg{
+		AccountId: "test_official",
+		Limit:     100,
+	}, func(ret *official.FetchSubscribersRet) {
+		for _, subscriber := range ret.List {
+			t.Log(subscriber.UserId, subscriber.SubscribeTime)
+		}
+	})
+	if err != nil {
+		handleError(t, "official.PullSubscribers", err)
+	}
+}
+
+// 公众号发送消息
+func TestIm_Official_SendMessage(t *testing.T) {
+	message := official.NewMessage()
+	message.SetContent(private.MsgTextContent{
+		Text: "Hello subscribers",
+	})
+
+	ret, err := NewIM().Official().SendMessage("test_official", message)
+	if err != nil {
+		handleError(t, "official.SendMessage", err)
+	}
+
+	t.Log(ret)
+}
+
+// 应用迁移（演练模式）
+func TestIm_Migrate_DryRun(t *testing.T) {
+	migrator := migrate.NewMigrator(NewIM(), NewIM(), &migrate.Options{
+		UserIds: testUserIds(),
+		DryRun:  true,
+		OnProgress: func(progress *migrate.Progress) {
+			t.Log(progress.Stage, progress.Item, progress.Done, progress.Total, progress.Error)
+		},
+	})
+
+	report, err := migrator.Run()
+	if err != nil {
+		handleError(t, "migrate.Migrator.Run", err)
+	}
+
+	for _, stage := range report.Stages {
+		t.Log(stage.Stage, stage.Total, stage.Succeeded, stage.Failed, stage.Records)
+	}
+}
+
+// 备份导出
+func TestIm_Backup_Export(t *testing.T) {
+	file, err := os.Create(os.TempDir() + "/tim_backup.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	summary, err := backup.NewExporter(NewIM(), &backup.Options{
+		UserIds:   testUserIds(),
+		StateFile: os.TempDir() + "/tim_backup.state",
+	}).Export(file)
+	if err != nil {
+		handleError(t, "backup.Exporter.Export", err)
+	}
+
+	t.Log(summary.Counts, summary.SkippedGroups)
+}
@@ -0,0 +1,108 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 14:05
+ * @Desc: 断点记录，用于批量任务的断点续传
+ */
+
+package checkpoint
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Checkpoint 断点记录
+// 已完成的任务标识按行追加写入断点文件，进程崩溃后重新打开同一文件即可跳过已完成的任务。
+// 断点文件路径为空时，仅在内存中记录。
+type Checkpoint struct {
+	mu   sync.Mutex
+	file *os.File
+	done map[string]struct{}
+}
+
+// Open 打开断点文件，文件不存在时自动创建
+func Open(path string) (*Checkpoint, error) {
+	c := &Checkpoint{done: make(map[string]struct{})}
+
+	if path == "" {
+		return c, nil
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if key := strings.TrimSpace(scanner.Text()); key != "" {
+			c.done[key] = struct{}{}
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	c.file = file
+
+	return c, nil
+}
+
+// Done 任务是否已完成
+func (c *Checkpoint) Done(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.done[key]
+
+	return ok
+}
+
+// Mark 标记任务已完成
+func (c *Checkpoint) Mark(key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.done[key]; ok {
+		return nil
+	}
+
+	if c.file != nil {
+		if _, err := c.file.WriteString(key + "\n"); err != nil {
+			return err
+		}
+	}
+
+	c.done[key] = struct{}{}
+
+	return nil
+}
+
+// Count 已完成的任务数
+func (c *Checkpoint) Count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.done)
+}
+
+// Close 关闭断点文件
+func (c *Checkpoint) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.file == nil {
+		return nil
+	}
+
+	err := c.file.Close()
+	c.file = nil
+
+	return err
+}
@@ -8,6 +8,8 @@
 package enum
 
 import (
+	"strings"
+
 	"github.com/default-yarns/tencent-im/internal/types"
 )
 
@@ -92,4 +94,17 @@ const (
 	// iOS10的推送扩展开关
 	MutableContentNormal types.MutableContent = 0 // 关闭iOS10的推送扩展
 	MutableContentEnable types.MutableContent = 1 // 开启iOS10的推送扩展
+
+	// 消息扩展操作类型
+	MsgExtensionOperateSet    = 1 // 设置扩展
+	MsgExtensionOperateDelete = 2 // 删除扩展
+	MsgExtensionOperateClear  = 3 // 清空扩展
+
+	// 机器人账号前缀
+	RobotUserIdPrefix = "@RBT#" // 机器人账号的 UserID 必须以该前缀开头
 )
+
+// IsRobot 判断 UserID 是否为机器人账号，供 account 与 callback 共用
+func IsRobot(userId string) bool {
+	return strings.HasPrefix(userId, RobotUserIdPrefix)
+}
@@ -0,0 +1,58 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 14:10
+ * @Desc: 接口调用频率限制
+ */
+
+package limiter
+
+import (
+	"context"
+	"time"
+)
+
+// Limiter 频率限制器
+// 按固定间隔发放调用许可，保证每秒的调用次数不超过设定值。
+type Limiter struct {
+	ticker *time.Ticker
+}
+
+// New 创建频率限制器，qps 小于等于0时不做限制
+func New(qps int) *Limiter {
+	l := &Limiter{}
+
+	if qps > 0 {
+		l.ticker = time.NewTicker(time.Second / time.Duration(qps))
+	}
+
+	return l
+}
+
+// Wait 等待调用许可
+func (l *Limiter) Wait() {
+	if l.ticker != nil {
+		<-l.ticker.C
+	}
+}
+
+// WaitContext 等待调用许可，上下文结束时返回上下文的错误
+func (l *Limiter) WaitContext(ctx context.Context) error {
+	if l.ticker == nil {
+		return ctx.Err()
+	}
+
+	select {
+	case <-l.ticker.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stop 停止频率限制器
+func (l *Limiter) Stop() {
+	if l.ticker != nil {
+		l.ticker.Stop()
+	}
+}
@@ -139,6 +139,19 @@ type (
 		Url    string `json:"URL"`    // （必填）图片下载地址。
 	}
 
+	// MsgExtension 消息扩展项
+	MsgExtension struct {
+		Key   string `json:"Key"`   // 扩展的键，最长100字节
+		Value string `json:"Value"` // 扩展的值，最长1K字节
+		Seq   int    `json:"Seq"`   // 扩展的版本号，修改时需填写当前最新的版本号（新增的键填0），版本号不一致时修改失败
+	}
+
+	// MsgExtensionResult 消息扩展操作结果项
+	MsgExtensionResult struct {
+		ErrorCode int          `json:"ErrorCode"` // 单个扩展项的操作结果：0表示成功；非0表示失败，版本号冲突时 Extension 为后台最新的扩展项
+		Extension MsgExtension `json:"Extension"` // 操作后的扩展项
+	}
+
 	// GenderType 性别类型
 	GenderType string
 
@@ -0,0 +1,654 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 16:00
+ * @Desc: 应用迁移，将一个 SDKAppID 的数据完整迁移至另一个 SDKAppID
+ */
+
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/default-yarns/tencent-im/group"
+	"github.com/default-yarns/tencent-im/internal/checkpoint"
+	"github.com/default-yarns/tencent-im/internal/core"
+	"github.com/default-yarns/tencent-im/internal/enum"
+	"github.com/default-yarns/tencent-im/profile"
+	"github.com/default-yarns/tencent-im/sns"
+)
+
+const (
+	batchImportAccountsLimit  = 100  // 每次导入账号的最大数量
+	batchGetProfilesLimit     = 100  // 每次拉取资料的最大数量
+	batchImportFriendsLimit   = 100  // 每次导入好友的最大数量
+	batchAddFriendGroupLimit  = 100  // 每次添加好友分组的最大数量
+	batchAddBlacklistLimit    = 1000 // 每次添加黑名单的最大数量
+	batchFetchGroupsLimit     = 50   // 每次拉取群组的最大数量
+	batchFetchMembersLimit    = 500  // 每次拉取群成员的最大数量
+	batchImportMembersLimit   = 300  // 每次导入群成员的最大数量
+	batchFetchMessagesLimit   = 20   // 每次拉取群历史消息的最大数量
+	defaultMessageConcurrency = 5    // 默认并发迁移群历史消息的群组数
+	memberRoleOwner           = "Owner"
+)
+
+var errProfileNotFound = core.NewError(enum.InvalidResponseCode, "the profile is not found")
+
+// 默认迁移的资料字段
+var defaultProfileAttrs = []string{
+	profile.StandardAttrNickname,
+	profile.StandardAttrGender,
+	profile.StandardAttrBirthday,
+	profile.StandardAttrLocation,
+	profile.StandardAttrSignature,
+	profile.StandardAttrAllowType,
+	profile.StandardAttrLanguage,
+	profile.StandardAttrAvatar,
+	profile.StandardAttrMsgSettings,
+	profile.StandardAttrAdminForbidType,
+	profile.StandardAttrLevel,
+	profile.StandardAttrRole,
+}
+
+// Migrator 应用迁移器
+// 按账号、资料、好友分组、好友、黑名单、群组、群成员、群历史消息的顺序，将源应用的数据迁移至目标应用。
+// 每个迁移项完成后记录断点，中断后使用相同的断点文件重新执行即可跳过已完成的迁移项。
+type Migrator struct {
+	source  Client
+	target  Client
+	opt     Options
+	cp      *checkpoint.Checkpoint
+	friends map[string][]*sns.Friend
+	groups  []*group.Group
+}
+
+func NewMigrator(source, target Client, opt *Options) *Migrator {
+	m := &Migrator{source: source, target: target}
+
+	if opt != nil {
+		m.opt = *opt
+	}
+
+	if len(m.opt.Stages) == 0 {
+		m.opt.Stages = defaultStages
+	}
+
+	if len(m.opt.ProfileAttrs) == 0 {
+		m.opt.ProfileAttrs = defaultProfileAttrs
+	}
+
+	return m
+}
+
+// Run 执行迁移
+// 单个迁移项失败不会中断迁移，失败原因记录在迁移报告中；仅在断点文件读写失败时返回错误。
+func (m *Migrator) Run() (report *Report, err error) {
+	path := m.opt.CheckpointFile
+	if m.opt.DryRun {
+		path = ""
+	}
+
+	if m.cp, err = checkpoint.Open(path); err != nil {
+		return
+	}
+	defer m.cp.Close()
+
+	m.friends = make(map[string][]*sns.Friend)
+	m.groups = nil
+
+	report = &Report{DryRun: m.opt.DryRun}
+
+	for _, stage := range m.opt.Stages {
+		sr := &StageReport{Stage: stage}
+		report.Stages = append(report.Stages, sr)
+
+		switch stage {
+		case StageAccounts:
+			err = m.migrateAccounts(sr)
+		case StageProfiles:
+			err = m.migrateProfiles(sr)
+		case StageFriendGroups:
+			err = m.each(sr, m.opt.UserIds, m.migrateFriendGroups)
+		case StageFriends:
+			err = m.each(sr, m.opt.UserIds, m.migrateFriends)
+		case StageBlacklists:
+			err = m.each(sr, m.opt.UserIds, m.migrateBlacklist)
+		case StageGroups:
+			err = m.eachGroup(sr, false, m.migrateGroup)
+		case StageMembers:
+			err = m.eachGroup(sr, true, m.migrateMembers)
+		case StageMessages:
+			err = m.migrateMessages(sr)
+		default:
+			err = core.NewError(enum.InvalidParamsCode, fmt.Sprintf("invalid migration stage: %s", stage))
+		}
+
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// each 逐项执行迁移
+func (m *Migrator) each(sr *StageReport, items []string, fn func(item string) (records int, err error)) error {
+	sr.Total = len(items)
+
+	for i, item := range items {
+		if m.cp.Done(m.key(sr.Stage, item)) {
+			m.skip(sr, item, i+1)
+			continue
+		}
+
+		records, err := fn(item)
+		if err = m.finish(sr, item, i+1, records, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// eachGroup 逐个群组执行迁移
+func (m *Migrator) eachGroup(sr *StageReport, skipLiveRoom bool, fn func(g *group.Group) (records int, err error)) error {
+	groups, err := m.loadGroups()
+	if err != nil {
+		sr.Failed++
+		sr.Failures = append(sr.Failures, &Failure{Error: err})
+		m.notify(&Progress{Stage: sr.Stage, DryRun: m.opt.DryRun, Error: err})
+		return nil
+	}
+
+	items := make([]string, 0, len(groups))
+	index := make(map[string]*group.Group, len(groups))
+	for _, g := range groups {
+		if skipLiveRoom && g.GetGroupType() == group.TypeLiveRoom {
+			continue
+		}
+		items = append(items, g.GetGroupId())
+		index[g.GetGroupId()] = g
+	}
+
+	return m.each(sr, items, func(groupId string) (int, error) {
+		return fn(index[groupId])
+	})
+}
+
+// migrateAccounts 迁移账号
+func (m *Migrator) migrateAccounts(sr *StageReport) error {
+	sr.Total = len(m.opt.UserIds)
+
+	pending := make([]string, 0, len(m.opt.UserIds))
+	for i, userId := range m.opt.UserIds {
+		if m.cp.Done(m.key(sr.Stage, userId)) {
+			m.skip(sr, userId, i+1)
+			continue
+		}
+		pending = append(pending, userId)
+	}
+
+	done := sr.Skipped
+	for _, userIds := range chunk(pending, batchImportAccountsLimit) {
+		var (
+			err      error
+			failures = make(map[string]struct{})
+		)
+
+		if !m.opt.DryRun {
+			var failUserIds []string
+			if failUserIds, err = m.target.Account().ImportAccounts(userIds...); err == nil {
+				for _, userId := range failUserIds {
+					failures[userId] = struct{}{}
+				}
+			}
+		}
+
+		for _, userId := range userIds {
+			done++
+
+			e := err
+			if _, ok := failures[userId]; ok && e == nil {
+				e = core.NewError(enum.InvalidResponseCode, "import account failed")
+			}
+
+			if e = m.finish(sr, userId, done, 1, e); e != nil {
+				return e
+			}
+		}
+	}
+
+	return nil
+}
+
+// migrateProfiles 迁移资料
+func (m *Migrator) migrateProfiles(sr *StageReport) error {
+	pending := make([]string, 0, len(m.opt.UserIds))
+	for _, userId := range m.opt.UserIds {
+		if !m.cp.Done(m.key(sr.Stage, userId)) {
+			pending = append(pending, userId)
+		}
+	}
+
+	var (
+		profiles = make(map[string]*profile.Profile, len(pending))
+		errs     = make(map[string]error)
+	)
+
+	for _, userIds := range chunk(pending, batchGetProfilesLimit) {
+		items, err := m.source.Profile().GetProfiles(userIds, m.opt.ProfileAttrs)
+		if err != nil {
+			for _, userId := range userIds {
+				errs[userId] = err
+			}
+			continue
+		}
+
+		for _, item := range items {
+			profiles[item.GetUserId()] = item
+		}
+	}
+
+	return m.each(sr, m.opt.UserIds, func(userId string) (int, error) {
+		if err, ok := errs[userId]; ok {
+			return 0, err
+		}
+
+		p, ok := profiles[userId]
+		if !ok {
+			return 0, errProfileNotFound
+		}
+
+		if err := p.GetError(); err != nil {
+			return 0, err
+		}
+
+		if len(p.GetAttrs()) == 0 {
+			return 0, nil
+		}
+
+		if !m.opt.DryRun {
+			if err := m.target.Profile().SetProfile(p); err != nil {
+				return 0, err
+			}
+		}
+
+		return len(p.GetAttrs()), nil
+	})
+}
+
+// migrateFriendGroups 迁移好友分组
+func (m *Migrator) migrateFriendGroups(userId string) (records int, err error) {
+	friends, err := m.loadFriends(userId)
+	if err != nil {
+		return
+	}
+
+	var (
+		names = make([]string, 0)
+		exist = make(map[string]struct{})
+	)
+
+	for _, friend := range friends {
+		groups, _ := friend.GetGroup()
+		for _, name := range groups {
+			if _, ok := exist[name]; !ok {
+				exist[name] = struct{}{}
+				names = append(names, name)
+			}
+		}
+	}
+
+	if !m.opt.DryRun {
+		for _, batch := range chunk(names, batchAddFriendGroupLimit) {
+			if _, _, err = m.target.SNS().AddGroups(userId, batch); err != nil {
+				return
+			}
+		}
+	}
+
+	records = len(names)
+
+	return
+}
+
+// migrateFriends 迁移好友
+func (m *Migrator) migrateFriends(userId string) (records int, err error) {
+	friends, err := m.loadFriends(userId)
+	if err != nil {
+		return
+	}
+
+	if !m.opt.DryRun {
+		for i := 0; i < len(friends); i += batchImportFriendsLimit {
+			end := i + batchImportFriendsLimit
+			if end > len(friends) {
+				end = len(friends)
+			}
+
+			var results []*sns.Result
+			if results, err = m.target.SNS().ImportFriends(userId, friends[i:end]...); err != nil {
+				return
+			}
+
+			for _, result := range results {
+				if result.ResultCode != enum.SuccessCode {
+					err = core.NewError(result.ResultCode, fmt.Sprintf("import friend %s failed: %s", result.UserId, result.ResultInfo))
+					return
+				}
+			}
+		}
+	}
+
+	records = len(friends)
+
+	return
+}
+
+// migrateBlacklist 迁移黑名单
+func (m *Migrator) migrateBlacklist(userId string) (records int, err error) {
+	userIds := make([]string, 0)
+
+	if err = m.source.SNS().PullBlacklist(userId, batchAddBlacklistLimit, func(ret *sns.FetchBlacklistRet) {
+		for _, item := range ret.List {
+			userIds = append(userIds, item.UserId)
+		}
+	}); err != nil {
+		return
+	}
+
+	if !m.opt.DryRun {
+		for _, batch := range chunk(userIds, batchAddBlacklistLimit) {
+			var results []*sns.Result
+			if results, err = m.target.SNS().AddBlacklist(userId, batch...); err != nil {
+				return
+			}
+
+			for _, result := range results {
+				if result.ResultCode != enum.SuccessCode {
+					err = core.NewError(result.ResultCode, fmt.Sprintf("add blacklist %s failed: %s", result.UserId, result.ResultInfo))
+					return
+				}
+			}
+		}
+	}
+
+	records = len(userIds)
+
+	return
+}
+
+// migrateGroup 迁移群组基础资料
+func (m *Migrator) migrateGroup(g *group.Group) (records int, err error) {
+	if !m.opt.DryRun {
+		if _, err = m.target.Group().ImportGroup(g); err != nil {
+			return
+		}
+	}
+
+	records = 1
+
+	return
+}
+
+// migrateMembers 迁移群成员
+func (m *Migrator) migrateMembers(g *group.Group) (records int, err error) {
+	members := make([]*group.Member, 0)
+
+	if err = m.source.Group().PullMembers(&group.PullMembersArg{
+		GroupId: g.GetGroupId(),
+		Limit:   batchFetchMembersLimit,
+	}, func(ret *group.FetchMembersRet) {
+		for _, member := range ret.List {
+			if member.GetRole() != memberRoleOwner {
+				members = append(members, member)
+			}
+		}
+	}); err != nil {
+		return
+	}
+
+	if !m.opt.DryRun {
+		for i := 0; i < len(members); i += batchImportMembersLimit {
+			end := i + batchImportMembersLimit
+			if end > len(members) {
+				end = len(members)
+			}
+
+			var results []group.ImportMemberResult
+			if results, err = m.target.Group().ImportMembers(g.GetGroupId(), members[i:end]...); err != nil {
+				return
+			}
+
+			for _, result := range results {
+				if result.Result == 0 {
+					err = core.NewError(enum.InvalidResponseCode, fmt.Sprintf("import member %s failed", result.UserId))
+					return
+				}
+			}
+		}
+	}
+
+	records = len(members)
+
+	return
+}
+
+// migrateMessages 迁移群历史消息
+// 多个协程逐个群组拉取历史消息并立即通过群消息批量导入器导入，内存中最多只保留 MessageConcurrency 个群组的消息；
+// 导入器自身也会记录消息级别的断点。
+func (m *Migrator) migrateMessages(sr *StageReport) error {
+	groups, err := m.loadGroups()
+	if err != nil {
+		sr.Failed++
+		sr.Failures = append(sr.Failures, &Failure{Error: err})
+		m.notify(&Progress{Stage: sr.Stage, DryRun: m.opt.DryRun, Error: err})
+		return nil
+	}
+
+	groupIds := make([]string, 0, len(groups))
+	for _, g := range groups {
+		if g.GetGroupType() != group.TypeLiveRoom {
+			groupIds = append(groupIds, g.GetGroupId())
+		}
+	}
+	sr.Total = len(groupIds)
+
+	opt := &group.ImporterOptions{Concurrency: 1}
+	if m.opt.CheckpointFile != "" && !m.opt.DryRun {
+		opt.CheckpointFile = m.opt.CheckpointFile + ".messages"
+	}
+	importer := group.NewImporter(m.target.Group(), opt)
+	defer importer.Close()
+
+	type outcome struct {
+		groupId string
+		records int
+		err     error
+	}
+
+	workers := m.opt.MessageConcurrency
+	if workers <= 0 {
+		workers = defaultMessageConcurrency
+	}
+
+	var (
+		wg       sync.WaitGroup
+		queue    = make(chan string)
+		outcomes = make(chan *outcome)
+	)
+
+	for n := 0; n < workers; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for groupId := range queue {
+				records, err := m.migrateGroupMessages(importer, groupId)
+				outcomes <- &outcome{groupId: groupId, records: records, err: err}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(queue)
+		for _, groupId := range groupIds {
+			if !m.cp.Done(m.key(sr.Stage, groupId)) {
+				queue <- groupId
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(outcomes)
+	}()
+
+	done := 0
+	for _, groupId := range groupIds {
+		if m.cp.Done(m.key(sr.Stage, groupId)) {
+			done++
+			m.skip(sr, groupId, done)
+		}
+	}
+
+	var fatal error
+	for o := range outcomes {
+		if fatal != nil {
+			continue
+		}
+
+		done++
+		if e := m.finish(sr, o.groupId, done, o.records, o.err); e != nil {
+			fatal = e
+		}
+	}
+
+	return fatal
+}
+
+// migrateGroupMessages 拉取单个群组的历史消息并导入目标应用
+func (m *Migrator) migrateGroupMessages(importer *group.Importer, groupId string) (records int, err error) {
+	messages := make([]*group.Message, 0)
+	if err = m.source.Group().PullMessages(groupId, batchFetchMessagesLimit, func(ret *group.FetchMessagesRet) {
+		for _, message := range ret.List {
+			if message.GetStatus() == group.MsgStatusNormal {
+				message.SetSendTime(message.GetTimestamp())
+				messages = append(messages, message)
+			}
+		}
+	}); err != nil {
+		return
+	}
+
+	records = len(messages)
+	if m.opt.DryRun {
+		return
+	}
+
+	results, err := importer.ImportGroup(context.Background(), groupId, messages...)
+	if err != nil {
+		return
+	}
+
+	for _, result := range results {
+		if result.Status == group.ImportStatusFailed {
+			return records, result.Error
+		}
+	}
+
+	return
+}
+
+// loadFriends 拉取并缓存源应用中用户的好友
+func (m *Migrator) loadFriends(userId string) (friends []*sns.Friend, err error) {
+	if friends, ok := m.friends[userId]; ok {
+		return friends, nil
+	}
+
+	friends = make([]*sns.Friend, 0)
+	if err = m.source.SNS().PullFriends(userId, func(ret *sns.FetchFriendsRet) {
+		friends = append(friends, ret.List...)
+	}); err != nil {
+		return
+	}
+
+	m.friends[userId] = friends
+
+	return
+}
+
+// loadGroups 拉取并缓存源应用中的群组
+func (m *Migrator) loadGroups() (groups []*group.Group, err error) {
+	if m.groups != nil {
+		return m.groups, nil
+	}
+
+	groups = make([]*group.Group, 0)
+	if err = m.source.Group().PullGroups(&group.PullGroupsArg{
+		Limit: batchFetchGroupsLimit,
+		Type:  m.opt.GroupType,
+	}, func(ret *group.FetchGroupsRet) {
+		groups = append(groups, ret.List...)
+	}); err != nil {
+		return
+	}
+
+	m.groups = groups
+
+	return
+}
+
+// skip 记录断点跳过的迁移项
+func (m *Migrator) skip(sr *StageReport, item string, done int) {
+	sr.Skipped++
+	m.notify(&Progress{Stage: sr.Stage, Item: item, Done: done, Total: sr.Total, Skipped: true, DryRun: m.opt.DryRun})
+}
+
+// finish 记录迁移项的执行结果
+func (m *Migrator) finish(sr *StageReport, item string, done, records int, err error) error {
+	progress := &Progress{Stage: sr.Stage, Item: item, Done: done, Total: sr.Total, DryRun: m.opt.DryRun, Error: err}
+
+	if err != nil {
+		sr.Failed++
+		sr.Failures = append(sr.Failures, &Failure{Item: item, Error: err})
+	} else {
+		sr.Succeeded++
+		sr.Records += records
+		if !m.opt.DryRun {
+			if err = m.cp.Mark(m.key(sr.Stage, item)); err != nil {
+				return err
+			}
+		}
+	}
+
+	m.notify(progress)
+
+	return nil
+}
+
+// notify 通知迁移进度
+func (m *Migrator) notify(progress *Progress) {
+	if m.opt.OnProgress != nil {
+		m.opt.OnProgress(progress)
+	}
+}
+
+// key 断点记录标识
+func (m *Migrator) key(stage Stage, item string) string {
+	return string(stage) + "|" + item
+}
+
+// chunk 按指定大小分批
+func chunk(items []string, size int) (batches [][]string) {
+	for i := 0; i < len(items); i += size {
+		end := i + size
+		if end > len(items) {
+			end = len(items)
+		}
+		batches = append(batches, items[i:end])
+	}
+
+	return
+}
@@ -0,0 +1,108 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 16:00
+ * @Desc: 应用迁移数据结构
+ */
+
+package migrate
+
+import (
+	"github.com/default-yarns/tencent-im/account"
+	"github.com/default-yarns/tencent-im/group"
+	"github.com/default-yarns/tencent-im/profile"
+	"github.com/default-yarns/tencent-im/sns"
+)
+
+// Stage 迁移阶段
+type Stage string
+
+const (
+	StageAccounts     Stage = "accounts"      // 账号
+	StageProfiles     Stage = "profiles"      // 资料
+	StageFriendGroups Stage = "friend_groups" // 好友分组
+	StageFriends      Stage = "friends"       // 好友
+	StageBlacklists   Stage = "blacklists"    // 黑名单
+	StageGroups       Stage = "groups"        // 群组
+	StageMembers      Stage = "members"       // 群成员
+	StageMessages     Stage = "messages"      // 群历史消息
+)
+
+// 默认的迁移阶段，按依赖关系排列
+var defaultStages = []Stage{
+	StageAccounts,
+	StageProfiles,
+	StageFriendGroups,
+	StageFriends,
+	StageBlacklists,
+	StageGroups,
+	StageMembers,
+	StageMessages,
+}
+
+type (
+	// Client 迁移所需的接口集合，IM 实例即实现了该接口
+	Client interface {
+		Account() account.API
+		Profile() profile.API
+		SNS() sns.API
+		Group() group.API
+	}
+
+	// Options 迁移配置
+	Options struct {
+		UserIds            []string                 // （必填）需要迁移的账号列表，即时通信 IM 不提供拉取全部账号的接口，需由业务方提供
+		Stages             []Stage                  // （选填）需要执行的迁移阶段，默认按依赖顺序执行全部阶段
+		ProfileAttrs       []string                 // （选填）需要迁移的资料字段，默认为全部标配资料字段
+		GroupType          group.Type               // （选填）仅迁移指定类型的群组，默认为全部类型
+		DryRun             bool                     // （选填）演练模式，仅读取源应用数据并生成报告，不向目标应用写入任何数据
+		CheckpointFile     string                   // （选填）断点文件路径，为空时不记录断点
+		MessageConcurrency int                      // （选填）并发导入群历史消息的群组数，默认为5
+		OnProgress         func(progress *Progress) // （选填）迁移进度通知
+	}
+
+	// Progress 迁移进度
+	Progress struct {
+		Stage   Stage  // 迁移阶段
+		Item    string // 迁移项，通常为 UserID 或群ID
+		Done    int    // 当前阶段已处理的迁移项数量
+		Total   int    // 当前阶段的迁移项总数
+		Skipped bool   // 是否因断点记录而跳过
+		DryRun  bool   // 是否为演练模式
+		Error   error  // 迁移失败的错误信息
+	}
+
+	// Failure 迁移失败项
+	Failure struct {
+		Item  string // 迁移项
+		Error error  // 错误信息
+	}
+
+	// StageReport 迁移阶段报告
+	StageReport struct {
+		Stage     Stage      // 迁移阶段
+		Total     int        // 迁移项总数
+		Succeeded int        // 迁移成功数
+		Failed    int        // 迁移失败数
+		Skipped   int        // 断点跳过数
+		Records   int        // 迁移的数据条数，如好友数、群成员数、消息数
+		Failures  []*Failure // 迁移失败项
+	}
+
+	// Report 迁移报告
+	Report struct {
+		DryRun bool           // 是否为演练模式
+		Stages []*StageReport // 各阶段的迁移报告
+	}
+)
+
+// Failed 是否存在迁移失败项
+func (r *Report) Failed() bool {
+	for _, stage := range r.Stages {
+		if stage.Failed > 0 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,243 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 14:02
+ * @Desc: 公众号实体
+ */
+
+package official
+
+import (
+	"time"
+
+	"github.com/default-yarns/tencent-im/internal/core"
+	"github.com/default-yarns/tencent-im/internal/enum"
+)
+
+var (
+	errNotSetAccountId     = core.NewError(enum.InvalidParamsCode, "official account id is not set")
+	errNotSetAccountName   = core.NewError(enum.InvalidParamsCode, "official account name is not set")
+	errAccountNameTooLong  = core.NewError(enum.InvalidParamsCode, "official account name is too long")
+	errNotSetAccountOwner  = core.NewError(enum.InvalidParamsCode, "official account owner is not set")
+	errIntroductionTooLong = core.NewError(enum.InvalidParamsCode, "official account introduction is too long")
+	errCustomStringTooLong = core.NewError(enum.InvalidParamsCode, "official account custom string is too long")
+	errNothingToUpdate     = core.NewError(enum.InvalidParamsCode, "official account has nothing to update")
+)
+
+const (
+	accountNameMaxLength         = 150  // 公众号名称最大长度（字节）
+	accountIntroductionMaxLength = 400  // 公众号简介最大长度（字节）
+	accountCustomStringMaxLength = 3000 // 公众号自定义数据最大长度（字节）
+)
+
+type Account struct {
+	err              error
+	id               string  // 公众号ID
+	name             string  // 公众号名称
+	owner            string  // 公众号所有者
+	avatar           *string // 公众号头像
+	introduction     *string // 公众号简介
+	organization     *string // 公众号所属组织
+	customString     *string // 公众号自定义数据
+	subscriberNum    uint    // 订阅者数量
+	maxSubscriberNum uint    // 最大订阅者数量
+	createTime       int64   // 创建时间
+	lastInfoTime     int64   // 最后资料变更时间
+	lastMsgTime      int64   // 最后一条消息的时间
+	nextMsgSeq       int     // 下一条消息的Seq
+}
+
+func NewAccount(id ...string) *Account {
+	account := &Account{}
+	if len(id) > 0 {
+		account.SetAccountId(id[0])
+	}
+	return account
+}
+
+// SetAccountId 设置公众号ID
+func (a *Account) SetAccountId(id string) {
+	a.id = id
+}
+
+// GetAccountId 获取公众号ID
+func (a *Account) GetAccountId() string {
+	return a.id
+}
+
+// SetName 设置公众号名称
+func (a *Account) SetName(name string) {
+	a.name = name
+}
+
+// GetName 获取公众号名称
+func (a *Account) GetName() string {
+	return a.name
+}
+
+// SetOwner 设置公众号所有者
+func (a *Account) SetOwner(owner string) {
+	a.owner = owner
+}
+
+// GetOwner 获取公众号所有者
+func (a *Account) GetOwner() string {
+	return a.owner
+}
+
+// SetAvatar 设置公众号头像
+func (a *Account) SetAvatar(avatar string) {
+	a.avatar = &avatar
+}
+
+// GetAvatar 获取公众号头像
+func (a *Account) GetAvatar() string {
+	if a.avatar == nil {
+		return ""
+	}
+	return *a.avatar
+}
+
+// SetIntroduction 设置公众号简介
+func (a *Account) SetIntroduction(introduction string) {
+	a.introduction = &introduction
+}
+
+// GetIntroduction 获取公众号简介
+func (a *Account) GetIntroduction() string {
+	if a.introduction == nil {
+		return ""
+	}
+	return *a.introduction
+}
+
+// SetOrganization 设置公众号所属组织
+func (a *Account) SetOrganization(organization string) {
+	a.organization = &organization
+}
+
+// GetOrganization 获取公众号所属组织
+func (a *Account) GetOrganization() string {
+	if a.organization == nil {
+		return ""
+	}
+	return *a.organization
+}
+
+// SetCustomString 设置公众号自定义数据
+func (a *Account) SetCustomString(customString string) {
+	a.customString = &customString
+}
+
+// GetCustomString 获取公众号自定义数据
+func (a *Account) GetCustomString() string {
+	if a.customString == nil {
+		return ""
+	}
+	return *a.customString
+}
+
+// SetMaxSubscriberNum 设置最大订阅者数量
+func (a *Account) SetMaxSubscriberNum(num uint) {
+	a.maxSubscriberNum = num
+}
+
+// GetMaxSubscriberNum 获取最大订阅者数量
+func (a *Account) GetMaxSubscriberNum() uint {
+	return a.maxSubscriberNum
+}
+
+// GetSubscriberNum 获取订阅者数量
+func (a *Account) GetSubscriberNum() uint {
+	return a.subscriberNum
+}
+
+// GetCreateTime 获取创建时间
+func (a *Account) GetCreateTime() time.Time {
+	return time.Unix(a.createTime, 0)
+}
+
+// GetLastInfoTime 获取最后资料变更时间
+func (a *Account) GetLastInfoTime() time.Time {
+	return time.Unix(a.lastInfoTime, 0)
+}
+
+// GetLastMsgTime 获取最后一条消息的时间
+func (a *Account) GetLastMsgTime() time.Time {
+	return time.Unix(a.lastMsgTime, 0)
+}
+
+// GetNextMsgSeq 获取下一条消息的Seq
+func (a *Account) GetNextMsgSeq() int {
+	return a.nextMsgSeq
+}
+
+// GetError 获取异常错误
+func (a *Account) GetError() error {
+	return a.err
+}
+
+// 设置异常错误
+func (a *Account) setError(code int, message string) {
+	if code != enum.SuccessCode {
+		a.err = core.NewError(code, message)
+	}
+}
+
+// 检测创建错误
+func (a *Account) checkCreateError() (err error) {
+	if a.owner == "" {
+		return errNotSetAccountOwner
+	}
+
+	if err = a.checkNameArgError(); err != nil {
+		return
+	}
+
+	return a.checkOptionalArgError()
+}
+
+// 检测修改错误
+func (a *Account) checkUpdateError() (err error) {
+	if a.id == "" {
+		return errNotSetAccountId
+	}
+
+	if a.name == "" && a.avatar == nil && a.introduction == nil && a.organization == nil && a.customString == nil {
+		return errNothingToUpdate
+	}
+
+	if a.name != "" {
+		if err = a.checkNameArgError(); err != nil {
+			return
+		}
+	}
+
+	return a.checkOptionalArgError()
+}
+
+// 检测名称参数错误
+func (a *Account) checkNameArgError() (err error) {
+	if a.name == "" {
+		return errNotSetAccountName
+	}
+
+	if len(a.name) > accountNameMaxLength {
+		return errAccountNameTooLong
+	}
+
+	return
+}
+
+// 检测选填参数错误
+func (a *Account) checkOptionalArgError() (err error) {
+	if a.introduction != nil && len(*a.introduction) > accountIntroductionMaxLength {
+		return errIntroductionTooLong
+	}
+
+	if a.customString != nil && len(*a.customString) > accountCustomStringMaxLength {
+		return errCustomStringTooLong
+	}
+
+	return
+}
@@ -0,0 +1,307 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 14:30
+ * @Desc: 公众号管理
+ */
+
+package official
+
+import (
+	"fmt"
+
+	"github.com/default-yarns/tencent-im/internal/conv"
+	"github.com/default-yarns/tencent-im/internal/core"
+	"github.com/default-yarns/tencent-im/internal/enum"
+	"github.com/default-yarns/tencent-im/internal/types"
+)
+
+const (
+	service                 = "official_account_open_http_svc"
+	commandCreateAccount    = "create_official_account"
+	commandDestroyAccount   = "destroy_official_account"
+	commandUpdateAccount    = "modify_official_account"
+	commandGetAccounts      = "get_official_account_info"
+	commandFetchSubscribers = "get_subscriber_member_info"
+	commandSendMessage      = "send_official_account_msg"
+
+	batchGetAccountsLimit = 50 // 批量获取公众号限制
+)
+
+type API interface {
+	// CreateAccount 创建公众号
+	// App 管理员可以通过该接口创建公众号，公众号创建后由所有者向订阅者推送消息。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/96448
+	CreateAccount(account *Account) (accountId string, err error)
+
+	// DestroyAccount 销毁公众号
+	// App 管理员通过该接口销毁公众号，销毁后订阅关系一并解除。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/96449
+	DestroyAccount(accountId string) (err error)
+
+	// UpdateAccount 修改公众号资料
+	// App 管理员可以通过该接口修改公众号的名称、头像、简介等资料，仅修改已设置的字段。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/96450
+	UpdateAccount(account *Account) (err error)
+
+	// GetAccount 获取单个公众号资料
+	// 本方法由“获取多个公众号资料（GetAccounts）”拓展而来
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/96451
+	GetAccount(accountId string) (account *Account, err error)
+
+	// GetAccounts 获取多个公众号资料
+	// App 管理员可以根据公众号 ID 获取公众号的详细资料，单次最多获取50个公众号。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/96451
+	GetAccounts(accountIds ...string) (accounts []*Account, err error)
+
+	// FetchSubscribers 获取公众号订阅者列表
+	// App 管理员可以根据公众号 ID 分页获取公众号的订阅者列表。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/96452
+	FetchSubscribers(accountId string, limit int, next ...string) (ret *FetchSubscribersRet, err error)
+
+	// PullSubscribers 续拉取公众号订阅者列表
+	// 本方法由“获取公众号订阅者列表（FetchSubscribers）”拓展而来
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/96452
+	PullSubscribers(arg *PullSubscribersArg, fn func(ret *FetchSubscribersRet)) (err error)
+
+	// SendMessage 公众号发送消息
+	// App 管理员可以通过该接口以公众号的身份向全部订阅者发送消息。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/96453
+	SendMessage(accountId string, message *Message) (ret *SendMessageRet, err error)
+}
+
+type api struct {
+	client core.Client
+}
+
+func NewAPI(client core.Client) API {
+	return &api{client: client}
+}
+
+// CreateAccount 创建公众号
+// App 管理员可以通过该接口创建公众号，公众号创建后由所有者向订阅者推送消息。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/96448
+func (a *api) CreateAccount(account *Account) (accountId string, err error) {
+	if err = account.checkCreateError(); err != nil {
+		return
+	}
+
+	req := &createAccountReq{}
+	req.OwnerUserId = account.owner
+	req.AccountId = account.id
+	req.Name = account.name
+	req.FaceUrl = account.avatar
+	req.Introduction = account.introduction
+	req.Organization = account.organization
+	req.CustomString = account.customString
+	req.MaxSubscriberNum = account.maxSubscriberNum
+
+	resp := &createAccountResp{}
+
+	if err = a.client.Post(service, commandCreateAccount, req, resp); err != nil {
+		return
+	}
+
+	accountId = resp.AccountId
+
+	return
+}
+
+// DestroyAccount 销毁公众号
+// App 管理员通过该接口销毁公众号，销毁后订阅关系一并解除。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/96449
+func (a *api) DestroyAccount(accountId string) (err error) {
+	req := &destroyAccountReq{AccountId: accountId}
+
+	if err = a.client.Post(service, commandDestroyAccount, req, &types.ActionBaseResp{}); err != nil {
+		return
+	}
+
+	return
+}
+
+// UpdateAccount 修改公众号资料
+// App 管理员可以通过该接口修改公众号的名称、头像、简介等资料，仅修改已设置的字段。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/96450
+func (a *api) UpdateAccount(account *Account) (err error) {
+	if err = account.checkUpdateError(); err != nil {
+		return
+	}
+
+	req := &updateAccountReq{}
+	req.AccountId = account.id
+	req.Name = account.name
+	req.FaceUrl = account.avatar
+	req.Introduction = account.introduction
+	req.Organization = account.organization
+	req.CustomString = account.customString
+
+	if err = a.client.Post(service, commandUpdateAccount, req, &types.ActionBaseResp{}); err != nil {
+		return
+	}
+
+	return
+}
+
+// GetAccount 获取单个公众号资料
+// 本方法由“获取多个公众号资料（GetAccounts）”拓展而来
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/96451
+func (a *api) GetAccount(accountId string) (account *Account, err error) {
+	var accounts []*Account
+
+	if accounts, err = a.GetAccounts(accountId); err != nil {
+		return
+	}
+
+	if len(accounts) > 0 {
+		if err = accounts[0].err; err != nil {
+			return
+		}
+
+		account = accounts[0]
+	}
+
+	return
+}
+
+// GetAccounts 获取多个公众号资料
+// App 管理员可以根据公众号 ID 获取公众号的详细资料，单次最多获取50个公众号。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/96451
+func (a *api) GetAccounts(accountIds ...string) (accounts []*Account, err error) {
+	if c := len(accountIds); c == 0 {
+		err = core.NewError(enum.InvalidParamsCode, "the official account's id is not set")
+		return
+	} else if c > batchGetAccountsLimit {
+		err = core.NewError(enum.InvalidParamsCode, fmt.Sprintf("the number of official account's id cannot exceed %d", batchGetAccountsLimit))
+		return
+	}
+
+	req := &getAccountsReq{Accounts: make([]accountIdItem, 0, len(accountIds))}
+	for _, accountId := range accountIds {
+		req.Accounts = append(req.Accounts, accountIdItem{AccountId: accountId})
+	}
+
+	resp := &getAccountsResp{}
+
+	if err = a.client.Post(service, commandGetAccounts, req, resp); err != nil {
+		return
+	}
+
+	accounts = make([]*Account, 0, len(resp.Accounts))
+	for _, item := range resp.Accounts {
+		account := NewAccount(item.AccountId)
+		account.setError(item.ErrorCode, item.ErrorInfo)
+		if account.err == nil {
+			account.name = item.Name
+			account.owner = item.OwnerUserId
+			account.SetAvatar(item.FaceUrl)
+			account.SetIntroduction(item.Introduction)
+			account.SetOrganization(item.Organization)
+			account.SetCustomString(item.CustomString)
+			account.subscriberNum = item.SubscriberNum
+			account.maxSubscriberNum = item.MaxSubscriberNum
+			account.createTime = item.CreateTime
+			account.lastInfoTime = item.LastInfoTime
+			account.lastMsgTime = item.LastMsgTime
+			account.nextMsgSeq = item.NextMsgSeq
+		}
+
+		accounts = append(accounts, account)
+	}
+
+	return
+}
+
+// FetchSubscribers 获取公众号订阅者列表
+// App 管理员可以根据公众号 ID 分页获取公众号的订阅者列表。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/96452
+func (a *api) FetchSubscribers(accountId string, limit int, next ...string) (ret *FetchSubscribersRet, err error) {
+	req := &fetchSubscribersReq{AccountId: accountId, Limit: limit}
+
+	if len(next) > 0 {
+		req.Next = next[0]
+	}
+
+	resp := &fetchSubscribersResp{}
+
+	if err = a.client.Post(service, commandFetchSubscribers, req, resp); err != nil {
+		return
+	}
+
+	ret = &FetchSubscribersRet{
+		Next:    resp.Next,
+		HasMore: resp.Next != "",
+		List:    resp.Subscribers,
+	}
+
+	return
+}
+
+// PullSubscribers 续拉取公众号订阅者列表
+// 本方法由“获取公众号订阅者列表（FetchSubscribers）”拓展而来
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/96452
+func (a *api) PullSubscribers(arg *PullSubscribersArg, fn func(ret *FetchSubscribersRet)) (err error) {
+	var (
+		ret  *FetchSubscribersRet
+		next string
+	)
+
+	for ret == nil || ret.HasMore {
+		ret, err = a.FetchSubscribers(arg.AccountId, arg.Limit, next)
+		if err != nil {
+			return
+		}
+
+		fn(ret)
+
+		if ret.HasMore {
+			next = ret.Next
+		}
+	}
+
+	return
+}
+
+// SendMessage 公众号发送消息
+// App 管理员可以通过该接口以公众号的身份向全部订阅者发送消息。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/96453
+func (a *api) SendMessage(accountId string, message *Message) (ret *SendMessageRet, err error) {
+	if err = message.checkSendError(); err != nil {
+		return
+	}
+
+	req := &sendMessageReq{}
+	req.AccountId = accountId
+	req.MsgBody = message.GetBody()
+	req.Random = message.GetRandom()
+	req.CloudCustomData = conv.String(message.GetCustomData())
+
+	resp := &sendMessageResp{}
+
+	if err = a.client.Post(service, commandSendMessage, req, resp); err != nil {
+		return
+	}
+
+	ret = &SendMessageRet{
+		MsgSeq:  resp.MsgSeq,
+		MsgTime: resp.MsgTime,
+	}
+
+	return
+}
@@ -0,0 +1,40 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 14:10
+ * @Desc: 公众号消息实体
+ */
+
+package official
+
+import (
+	"github.com/default-yarns/tencent-im/internal/entity"
+)
+
+type Message struct {
+	entity.Message
+	customData interface{} // 自定义数据
+}
+
+func NewMessage() *Message {
+	return &Message{}
+}
+
+// SetCustomData 设置自定义数据
+func (m *Message) SetCustomData(data interface{}) {
+	m.customData = data
+}
+
+// GetCustomData 获取自定义数据
+func (m *Message) GetCustomData() interface{} {
+	return m.customData
+}
+
+// 检测发送错误
+func (m *Message) checkSendError() (err error) {
+	if err = m.CheckBodyArgError(); err != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,134 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 14:15
+ * @Desc: 公众号管理
+ */
+
+package official
+
+import "github.com/default-yarns/tencent-im/internal/types"
+
+type (
+	// 创建公众号（请求）
+	createAccountReq struct {
+		OwnerUserId      string  `json:"Owner_Account"`                   // （必填）公众号所有者的 UserID
+		AccountId        string  `json:"OfficialAccountUserID,omitempty"` // （选填）自定义公众号 ID，不填时由后台自动分配
+		Name             string  `json:"Name"`                            // （必填）公众号名称，最长150字节
+		FaceUrl          *string `json:"FaceUrl,omitempty"`               // （选填）公众号头像 URL
+		Introduction     *string `json:"Introduction,omitempty"`          // （选填）公众号简介，最长400字节
+		Organization     *string `json:"Organization,omitempty"`          // （选填）公众号所属组织
+		CustomString     *string `json:"CustomString,omitempty"`          // （选填）公众号自定义数据，最长3000字节
+		MaxSubscriberNum uint    `json:"MaxSubscriberNum,omitempty"`      // （选填）最大订阅者数量
+	}
+
+	// 创建公众号（响应）
+	createAccountResp struct {
+		types.ActionBaseResp
+		AccountId string `json:"OfficialAccountUserID"` // 公众号 ID
+	}
+
+	// 销毁公众号（请求）
+	destroyAccountReq struct {
+		AccountId string `json:"OfficialAccountUserID"` // （必填）需要销毁的公众号 ID
+	}
+
+	// 修改公众号资料（请求）
+	updateAccountReq struct {
+		AccountId    string  `json:"OfficialAccountUserID"`  // （必填）需要修改资料的公众号 ID
+		Name         string  `json:"Name,omitempty"`         // （选填）公众号名称，最长150字节
+		FaceUrl      *string `json:"FaceUrl,omitempty"`      // （选填）公众号头像 URL
+		Introduction *string `json:"Introduction,omitempty"` // （选填）公众号简介，最长400字节
+		Organization *string `json:"Organization,omitempty"` // （选填）公众号所属组织
+		CustomString *string `json:"CustomString,omitempty"` // （选填）公众号自定义数据，最长3000字节
+	}
+
+	// 获取公众号资料（请求）
+	getAccountsReq struct {
+		Accounts []accountIdItem `json:"OfficialAccountIDList"` // （必填）需要获取资料的公众号 ID 列表
+	}
+
+	// 公众号ID
+	accountIdItem struct {
+		AccountId string `json:"OfficialAccountUserID"` // 公众号 ID
+	}
+
+	// 获取公众号资料（响应）
+	getAccountsResp struct {
+		types.ActionBaseResp
+		Accounts []*accountInfo `json:"OfficialAccountInfo"` // 公众号资料列表
+	}
+
+	// 公众号资料
+	accountInfo struct {
+		AccountId        string `json:"OfficialAccountUserID"` // 公众号 ID
+		ErrorCode        int    `json:"ErrorCode"`             // 错误码
+		ErrorInfo        string `json:"ErrorInfo"`             // 错误信息
+		Name             string `json:"Name"`                  // 公众号名称
+		OwnerUserId      string `json:"Owner_Account"`         // 公众号所有者
+		FaceUrl          string `json:"FaceUrl"`               // 公众号头像
+		Introduction     string `json:"Introduction"`          // 公众号简介
+		Organization     string `json:"Organization"`          // 公众号所属组织
+		CustomString     string `json:"CustomString"`          // 公众号自定义数据
+		CreateTime       int64  `json:"CreateTime"`            // 创建时间
+		LastInfoTime     int64  `json:"InfoSeq"`               // 最后资料变更时间
+		LastMsgTime      int64  `json:"LastMsgTime"`           // 最后一条消息的时间
+		NextMsgSeq       int    `json:"NextMsgSeq"`            // 下一条消息的Seq
+		SubscriberNum    uint   `json:"SubscriberNum"`         // 订阅者数量
+		MaxSubscriberNum uint   `json:"MaxSubscriberNum"`      // 最大订阅者数量
+	}
+
+	// 获取公众号订阅者列表（请求）
+	fetchSubscribersReq struct {
+		AccountId string `json:"OfficialAccountUserID"` // （必填）公众号 ID
+		Limit     int    `json:"Limit,omitempty"`       // （选填）单次拉取的订阅者数量，最大值为200
+		Next      string `json:"Next,omitempty"`        // （选填）分页拉取标志，第一次不填，以后填上一次返回的 Next
+	}
+
+	// 获取公众号订阅者列表（响应）
+	fetchSubscribersResp struct {
+		types.ActionBaseResp
+		Next        string        `json:"Next"`           // 分页拉取标志，为空时表示已拉取完毕
+		Subscribers []*Subscriber `json:"SubscriberInfo"` // 订阅者列表
+	}
+
+	// Subscriber 订阅者信息
+	Subscriber struct {
+		UserId        string `json:"Subscriber_Account"` // 订阅者的 UserID
+		SubscribeTime int64  `json:"SubscribeTime"`      // 订阅时间
+	}
+
+	// FetchSubscribersRet 获取公众号订阅者列表（返回）
+	FetchSubscribersRet struct {
+		Next    string        // 分页拉取的标志
+		HasMore bool          // 是否还有更多数据
+		List    []*Subscriber // 订阅者列表
+	}
+
+	// PullSubscribersArg 续拉取公众号订阅者列表（参数）
+	PullSubscribersArg struct {
+		AccountId string // （必填）公众号 ID
+		Limit     int    // （选填）单次拉取的订阅者数量，最大值为200
+	}
+
+	// 公众号发送消息（请求）
+	sendMessageReq struct {
+		AccountId       string           `json:"OfficialAccountUserID"`     // （必填）公众号 ID
+		Random          uint32           `json:"Random"`                    // （必填）无符号32位整数
+		MsgBody         []*types.MsgBody `json:"MsgBody"`                   // （必填）消息体
+		CloudCustomData string           `json:"CloudCustomData,omitempty"` // （选填）消息自定义数据
+	}
+
+	// 公众号发送消息（响应）
+	sendMessageResp struct {
+		types.ActionBaseResp
+		MsgTime int `json:"MsgTime"` // 消息时间戳，UNIX 时间戳
+		MsgSeq  int `json:"MsgSeq"`  // 消息序列号，唯一标示一条消息
+	}
+
+	// SendMessageRet 公众号发送消息（返回）
+	SendMessageRet struct {
+		MsgSeq  int // 消息序列号，唯一标示一条消息
+		MsgTime int // 消息发送的时间戳，对应后台server时间
+	}
+)
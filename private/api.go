@@ -10,7 +10,9 @@ package private
 import (
 	"github.com/default-yarns/tencent-im/internal/conv"
 	"github.com/default-yarns/tencent-im/internal/core"
+	"github.com/default-yarns/tencent-im/internal/enum"
 	"github.com/default-yarns/tencent-im/internal/types"
+	"github.com/default-yarns/tencent-im/recentcontact"
 )
 
 const (
@@ -20,8 +22,12 @@ const (
 	commandImportMessage       = "importmsg"
 	commandFetchMessages       = "admin_getroammsg"
 	commandRevokeMessage       = "admin_msgwithdraw"
+	commandDeleteMessages      = "delete_c2c_msg_ramble"
+	commandClearMessages       = "clear_c2c_msg_ramble"
 	commandSetMessageRead      = "admin_set_msg_read"
 	commandGetUnreadMessageNum = "get_c2c_unread_msg_num"
+	commandSetKeyValues        = "set_key_values"
+	commandGetKeyValues        = "get_key_values"
 )
 
 type API interface {
@@ -94,6 +100,33 @@ type API interface {
 	// https://cloud.tencent.com/document/product/269/38980
 	RevokeMessage(fromUserId, toUserId, msgKey string) (err error)
 
+	// DeleteMessage 单向删除单条单聊消息
+	// 本方法由“单向删除多条单聊消息（DeleteMessages）”拓展而来
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/105841
+	DeleteMessage(userId, peerUserId, msgKey string) (err error)
+
+	// DeleteMessages 单向删除多条单聊消息
+	// App 管理员可以通过该接口删除指定用户一侧的单聊漫游消息，会话对方的消息不受影响。
+	// 消息的 MsgKey 可通过 查询单聊消息（FetchMessages）或 发单聊消息之后回调 获得。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/105841
+	DeleteMessages(userId, peerUserId string, msgKeys ...string) (results map[string]int, err error)
+
+	// ClearMessages 单向清空单聊历史消息
+	// App 管理员可以通过该接口清空指定用户一侧与会话对方的全部单聊漫游消息，会话对方的消息不受影响。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/105842
+	ClearMessages(userId, peerUserId string) (err error)
+
+	// EraseSession 单向抹除单聊会话
+	// 本方法由“单向清空单聊历史消息（ClearMessages）”和“删除单个会话（recentcontact.DeleteSession）”组合而来
+	// 清空指定用户一侧的单聊历史消息，并删除该用户的会话（同时清理漫游消息），适用于“删除聊天”或用户数据抹除等场景。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/105842
+	// https://cloud.tencent.com/document/product/269/62119
+	EraseSession(userId, peerUserId string) (err error)
+
 	// SetMessageRead 设置单聊消息已读
 	// 设置用户的某个单聊会话的消息全部已读。
 	// 点击查看详细文档:
@@ -105,6 +138,40 @@ type API interface {
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/56043
 	GetUnreadMessageNum(userId string, peerUserIds ...string) (ret *GetUnreadMessageNumRet, err error)
+
+	// SetMessageExtensions 设置单聊消息扩展
+	// App 管理员可以通过该接口设置支持消息扩展的单聊消息的扩展项（如表情回复、投票等）。
+	// 消息扩展采用乐观锁控制并发：每个扩展项需携带当前最新的版本号（Seq），新增的键填0。
+	// 若版本号与后台不一致，该扩展项设置失败，返回结果中携带后台最新的扩展项，可据此重新设置。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/84084
+	SetMessageExtensions(fromUserId, toUserId, msgKey string, extensions ...*MessageExtension) (results []MessageExtensionResult, err error)
+
+	// DeleteMessageExtensions 删除单聊消息扩展
+	// 本方法由“设置单聊消息扩展（SetMessageExtensions）”拓展而来
+	// 删除扩展项同样需要携带当前最新的版本号（Seq）。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/84084
+	DeleteMessageExtensions(fromUserId, toUserId, msgKey string, extensions ...*MessageExtension) (results []MessageExtensionResult, err error)
+
+	// ClearMessageExtensions 清空单聊消息扩展
+	// 本方法由“设置单聊消息扩展（SetMessageExtensions）”拓展而来
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/84084
+	ClearMessageExtensions(fromUserId, toUserId, msgKey string) (err error)
+
+	// FetchMessageExtensions 获取单聊消息扩展
+	// App 管理员可以通过该接口获取单聊消息的扩展项。
+	// 若扩展项较多，需要根据返回的 NextSeq 进行续拉。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/84085
+	FetchMessageExtensions(fromUserId, toUserId, msgKey string, startSeq ...int) (ret *FetchMessageExtensionsRet, err error)
+
+	// PullMessageExtensions 续拉取单聊消息扩展
+	// 本方法由“获取单聊消息扩展（FetchMessageExtensions）”拓展而来
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/84085
+	PullMessageExtensions(fromUserId, toUserId, msgKey string, fn func(ret *FetchMessageExtensionsRet)) (err error)
 }
 
 type api struct {
@@ -140,6 +207,7 @@ func (a *api) SendMessage(message *Message) (ret *SendMessageRet, err error) {
 	req.SendMsgControl = message.GetSendMsgControl()
 	req.ForbidCallbackControl = message.GetForbidCallbackControl()
 	req.SyncOtherMachine = message.GetSyncOtherMachine()
+	req.SupportExtension = message.GetSupportMessageExtension()
 
 	resp := &sendMessageResp{}
 
@@ -314,6 +382,83 @@ func (a *api) RevokeMessage(fromUserId, toUserId, msgKey string) (err error) {
 	return
 }
 
+// DeleteMessage 单向删除单条单聊消息
+// 本方法由“单向删除多条单聊消息（DeleteMessages）”拓展而来
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/105841
+func (a *api) DeleteMessage(userId, peerUserId, msgKey string) (err error) {
+	var results map[string]int
+
+	if results, err = a.DeleteMessages(userId, peerUserId, msgKey); err != nil {
+		return
+	}
+
+	if results[msgKey] != enum.SuccessCode {
+		err = core.NewError(results[msgKey], "message delete failed")
+		return
+	}
+
+	return
+}
+
+// DeleteMessages 单向删除多条单聊消息
+// App 管理员可以通过该接口删除指定用户一侧的单聊漫游消息，会话对方的消息不受影响。
+// 消息的 MsgKey 可通过 查询单聊消息（FetchMessages）或 发单聊消息之后回调 获得。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/105841
+func (a *api) DeleteMessages(userId, peerUserId string, msgKeys ...string) (results map[string]int, err error) {
+	if len(msgKeys) == 0 {
+		err = core.NewError(enum.InvalidParamsCode, "the message keys is not set")
+		return
+	}
+
+	req := &deleteMessagesReq{UserId: userId, PeerUserId: peerUserId, MsgKeys: msgKeys}
+	resp := &deleteMessagesResp{}
+
+	if err = a.client.Post(service, commandDeleteMessages, req, resp); err != nil {
+		return
+	}
+
+	results = make(map[string]int, len(resp.Results))
+	for _, item := range resp.Results {
+		results[item.MsgKey] = item.RetCode
+	}
+
+	return
+}
+
+// ClearMessages 单向清空单聊历史消息
+// App 管理员可以通过该接口清空指定用户一侧与会话对方的全部单聊漫游消息，会话对方的消息不受影响。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/105842
+func (a *api) ClearMessages(userId, peerUserId string) (err error) {
+	req := &clearMessagesReq{UserId: userId, PeerUserId: peerUserId}
+
+	if err = a.client.Post(service, commandClearMessages, req, &types.ActionBaseResp{}); err != nil {
+		return
+	}
+
+	return
+}
+
+// EraseSession 单向抹除单聊会话
+// 本方法由“单向清空单聊历史消息（ClearMessages）”和“删除单个会话（recentcontact.DeleteSession）”组合而来
+// 清空指定用户一侧的单聊历史消息，并删除该用户的会话（同时清理漫游消息），适用于“删除聊天”或用户数据抹除等场景。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/105842
+// https://cloud.tencent.com/document/product/269/62119
+func (a *api) EraseSession(userId, peerUserId string) (err error) {
+	if err = a.ClearMessages(userId, peerUserId); err != nil {
+		return
+	}
+
+	if err = recentcontact.NewAPI(a.client).DeleteSession(userId, peerUserId, recentcontact.SessionTypeC2C, true); err != nil {
+		return
+	}
+
+	return
+}
+
 // SetMessageRead 设置单聊消息已读
 // 设置用户的某个单聊会话的消息全部已读。
 // 点击查看详细文档:
@@ -352,3 +497,116 @@ func (a *api) GetUnreadMessageNum(userId string, peerUserIds ...string) (ret *Ge
 
 	return
 }
+
+// SetMessageExtensions 设置单聊消息扩展
+// App 管理员可以通过该接口设置支持消息扩展的单聊消息的扩展项（如表情回复、投票等）。
+// 消息扩展采用乐观锁控制并发：每个扩展项需携带当前最新的版本号（Seq），新增的键填0。
+// 若版本号与后台不一致，该扩展项设置失败，返回结果中携带后台最新的扩展项，可据此重新设置。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/84084
+func (a *api) SetMessageExtensions(fromUserId, toUserId, msgKey string, extensions ...*MessageExtension) (results []MessageExtensionResult, err error) {
+	return a.operateMessageExtensions(fromUserId, toUserId, msgKey, enum.MsgExtensionOperateSet, extensions...)
+}
+
+// DeleteMessageExtensions 删除单聊消息扩展
+// 本方法由“设置单聊消息扩展（SetMessageExtensions）”拓展而来
+// 删除扩展项同样需要携带当前最新的版本号（Seq）。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/84084
+func (a *api) DeleteMessageExtensions(fromUserId, toUserId, msgKey string, extensions ...*MessageExtension) (results []MessageExtensionResult, err error) {
+	return a.operateMessageExtensions(fromUserId, toUserId, msgKey, enum.MsgExtensionOperateDelete, extensions...)
+}
+
+// ClearMessageExtensions 清空单聊消息扩展
+// 本方法由“设置单聊消息扩展（SetMessageExtensions）”拓展而来
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/84084
+func (a *api) ClearMessageExtensions(fromUserId, toUserId, msgKey string) (err error) {
+	_, err = a.operateMessageExtensions(fromUserId, toUserId, msgKey, enum.MsgExtensionOperateClear)
+	return
+}
+
+// operateMessageExtensions 操作单聊消息扩展
+func (a *api) operateMessageExtensions(fromUserId, toUserId, msgKey string, operateType int, extensions ...*MessageExtension) (results []MessageExtensionResult, err error) {
+	if operateType != enum.MsgExtensionOperateClear && len(extensions) == 0 {
+		err = core.NewError(enum.InvalidParamsCode, "the message extensions is not set")
+		return
+	}
+
+	req := &setMessageExtensionsReq{
+		FromUserId:    fromUserId,
+		ToUserId:      toUserId,
+		MsgKey:        msgKey,
+		OperateType:   operateType,
+		ExtensionList: extensions,
+	}
+	resp := &setMessageExtensionsResp{}
+
+	if err = a.client.Post(service, commandSetKeyValues, req, resp); err != nil {
+		return
+	}
+
+	results = resp.Results
+
+	return
+}
+
+// FetchMessageExtensions 获取单聊消息扩展
+// App 管理员可以通过该接口获取单聊消息的扩展项。
+// 若扩展项较多，需要根据返回的 NextSeq 进行续拉。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/84085
+func (a *api) FetchMessageExtensions(fromUserId, toUserId, msgKey string, startSeq ...int) (ret *FetchMessageExtensionsRet, err error) {
+	req := &fetchMessageExtensionsReq{FromUserId: fromUserId, ToUserId: toUserId, MsgKey: msgKey}
+
+	if len(startSeq) > 0 {
+		req.StartSeq = startSeq[0]
+	}
+
+	resp := &fetchMessageExtensionsResp{}
+
+	if err = a.client.Post(service, commandGetKeyValues, req, resp); err != nil {
+		return
+	}
+
+	ret = &FetchMessageExtensionsRet{
+		LatestSeq: resp.LatestSeq,
+		ClearSeq:  resp.ClearSeq,
+		HasMore:   resp.Complete == 0,
+		List:      resp.ExtensionList,
+	}
+
+	if count := len(resp.ExtensionList); count > 0 {
+		ret.NextSeq = resp.ExtensionList[count-1].Seq + 1
+	} else {
+		ret.HasMore = false
+	}
+
+	return
+}
+
+// PullMessageExtensions 续拉取单聊消息扩展
+// 本方法由“获取单聊消息扩展（FetchMessageExtensions）”拓展而来
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/84085
+func (a *api) PullMessageExtensions(fromUserId, toUserId, msgKey string, fn func(ret *FetchMessageExtensionsRet)) (err error) {
+	var (
+		ret      *FetchMessageExtensionsRet
+		startSeq int
+	)
+
+	for ret == nil || ret.HasMore {
+		ret, err = a.FetchMessageExtensions(fromUserId, toUserId, msgKey, startSeq)
+		if err != nil {
+			return
+		}
+
+		fn(ret)
+
+		if ret.HasMore {
+			startSeq = ret.NextSeq
+		}
+	}
+
+	return
+}
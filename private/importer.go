@@ -0,0 +1,385 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 14:20
+ * @Desc: 单聊消息批量导入
+ */
+
+package private
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/default-yarns/tencent-im/internal/checkpoint"
+	"github.com/default-yarns/tencent-im/internal/core"
+	"github.com/default-yarns/tencent-im/internal/enum"
+	"github.com/default-yarns/tencent-im/internal/limiter"
+)
+
+const (
+	defaultImportConcurrency   = 10          // 默认并发导入的会话数
+	defaultImportRateLimit     = 200         // 默认每秒调用导入接口的次数
+	defaultImportMaxRetries    = 3           // 默认的最大重试次数
+	defaultImportRetryInterval = time.Second // 默认的重试间隔
+)
+
+var errNotSetMsgTimestamp = errors.New("message timestamp is not set")
+
+// ErrImportAborted 同一会话内之前的消息导入失败，为保证消息顺序不再导入该会话的后续消息
+var ErrImportAborted = errors.New("import aborted because a previous message of the session failed")
+
+// ImportStatus 导入状态
+type ImportStatus string
+
+const (
+	ImportStatusSucceeded  ImportStatus = "succeeded"  // 导入成功
+	ImportStatusFailed     ImportStatus = "failed"     // 导入失败
+	ImportStatusSkipped    ImportStatus = "skipped"    // 断点记录中已导入，跳过
+	ImportStatusDuplicated ImportStatus = "duplicated" // 与其他消息重复，跳过
+)
+
+type (
+	// ImporterOptions 批量导入配置
+	ImporterOptions struct {
+		Concurrency    int           // （选填）并发导入的会话数，默认为10
+		RateLimit      int           // （选填）每秒最多调用导入接口的次数，默认为200
+		MaxRetries     int           // （选填）导入失败时的最大重试次数，默认为3，小于0时不重试
+		RetryInterval  time.Duration // （选填）重试间隔，默认为1秒
+		CheckpointFile string        // （选填）断点文件路径，为空时不记录断点
+	}
+
+	// ImportResult 单条消息导入结果
+	ImportResult struct {
+		FromUserId   string       // 消息发送方 UserID
+		ToUserId     string       // 消息接收方 UserID
+		MsgTimeStamp int64        // 消息时间戳
+		MsgSeq       int          // 消息序列号
+		MsgRandom    uint32       // 消息随机数
+		Attempts     int          // 调用导入接口的次数
+		Status       ImportStatus // 导入状态
+		Error        error        // 导入失败的错误信息
+	}
+
+	// ImportReport 批量导入报告
+	ImportReport struct {
+		Total      int             // 消息总数
+		Succeeded  int             // 导入成功数
+		Failed     int             // 导入失败数
+		Skipped    int             // 断点跳过数
+		Duplicated int             // 重复消息数
+		Results    []*ImportResult // 逐条消息的导入结果，按导入顺序排列，未设置时间戳与重复的消息排在最后
+	}
+
+	// Importer 单聊消息批量导入器
+	// 本导入器由“导入单聊消息（ImportMessage）”拓展而来。
+	// 导入前按消息时间戳、序列号对消息排序，并按发送方、接收方、时间戳、序列号、消息内容去重；
+	// 消息标识不包含随机数，未设置随机数的消息在每次执行时生成的随机数不同，不影响去重与断点续传。
+	// 每条消息需设置时间戳（SetTimestamp），未设置时间戳的消息直接记为导入失败。
+	// 不同会话之间并发导入，同一会话内严格按顺序导入；会话内任一消息最终导入失败后，该会话的后续消息记为失败（ErrImportAborted）。
+	Importer struct {
+		api      API
+		opt      ImporterOptions
+		mu       sync.Mutex
+		keys     map[string]struct{}
+		messages []*importItem
+		repeats  []*importItem
+	}
+
+	importItem struct {
+		key     string
+		message *Message
+		result  *ImportResult
+	}
+)
+
+func NewImporter(api API, opt ...*ImporterOptions) *Importer {
+	i := &Importer{
+		api:  api,
+		keys: make(map[string]struct{}),
+	}
+
+	if len(opt) > 0 && opt[0] != nil {
+		i.opt = *opt[0]
+	}
+
+	if i.opt.Concurrency <= 0 {
+		i.opt.Concurrency = defaultImportConcurrency
+	}
+
+	if i.opt.RateLimit <= 0 {
+		i.opt.RateLimit = defaultImportRateLimit
+	}
+
+	switch {
+	case i.opt.MaxRetries == 0:
+		i.opt.MaxRetries = defaultImportMaxRetries
+	case i.opt.MaxRetries < 0:
+		i.opt.MaxRetries = 0
+	}
+
+	if i.opt.RetryInterval <= 0 {
+		i.opt.RetryInterval = defaultImportRetryInterval
+	}
+
+	return i
+}
+
+// Add 添加待导入的消息
+func (i *Importer) Add(messages ...*Message) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for _, message := range messages {
+		item := newImportItem(message)
+
+		if item.result.MsgTimeStamp == 0 {
+			item.result.Status = ImportStatusFailed
+			item.result.Error = errNotSetMsgTimestamp
+			i.repeats = append(i.repeats, item)
+			continue
+		}
+
+		if _, ok := i.keys[item.key]; ok {
+			item.result.Status = ImportStatusDuplicated
+			i.repeats = append(i.repeats, item)
+			continue
+		}
+
+		i.keys[item.key] = struct{}{}
+		i.messages = append(i.messages, item)
+	}
+}
+
+// AddStream 从消息流中读取待导入的消息，直到消息流关闭
+func (i *Importer) AddStream(stream <-chan *Message) {
+	for message := range stream {
+		i.Add(message)
+	}
+}
+
+// Run 执行批量导入
+// 单条消息导入失败不会中断其他会话的导入，失败原因记录在导入报告中；仅在断点文件读写失败时返回错误。
+// 导入失败的消息不会记录到断点文件中，再次执行时将重新导入。
+func (i *Importer) Run() (report *ImportReport, err error) {
+	return i.RunContext(context.Background())
+}
+
+// RunContext 执行批量导入，上下文结束时停止导入与重试等待，未导入的消息记为失败并返回上下文的错误
+func (i *Importer) RunContext(ctx context.Context) (report *ImportReport, err error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	cp, err := checkpoint.Open(i.opt.CheckpointFile)
+	if err != nil {
+		return
+	}
+	defer cp.Close()
+
+	sort.SliceStable(i.messages, func(a, b int) bool {
+		return lessImportItem(i.messages[a], i.messages[b])
+	})
+
+	var (
+		sessions = make(map[string][]*importItem)
+		orders   = make([]string, 0)
+	)
+
+	for _, item := range i.messages {
+		session := sessionKey(item.result.FromUserId, item.result.ToUserId)
+		if _, ok := sessions[session]; !ok {
+			orders = append(orders, session)
+		}
+		sessions[session] = append(sessions[session], item)
+	}
+
+	var (
+		wg    sync.WaitGroup
+		once  sync.Once
+		queue = make(chan []*importItem)
+		limit = limiter.New(i.opt.RateLimit)
+	)
+	defer limit.Stop()
+
+	for n := 0; n < i.opt.Concurrency; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for items := range queue {
+				if e := i.importSession(ctx, cp, limit, items); e != nil {
+					once.Do(func() { err = e })
+				}
+			}
+		}()
+	}
+
+	for _, session := range orders {
+		queue <- sessions[session]
+	}
+	close(queue)
+	wg.Wait()
+
+	report = &ImportReport{}
+	for _, item := range append(i.messages, i.repeats...) {
+		report.Total++
+		switch item.result.Status {
+		case ImportStatusSucceeded:
+			report.Succeeded++
+		case ImportStatusFailed:
+			report.Failed++
+		case ImportStatusSkipped:
+			report.Skipped++
+		case ImportStatusDuplicated:
+			report.Duplicated++
+		}
+		report.Results = append(report.Results, item.result)
+	}
+
+	return
+}
+
+// importSession 按顺序导入单个会话的消息，任一消息最终导入失败后不再导入该会话的后续消息
+func (i *Importer) importSession(ctx context.Context, cp *checkpoint.Checkpoint, limit *limiter.Limiter, items []*importItem) error {
+	for _, item := range items {
+		item.result.Status = ""
+		item.result.Attempts = 0
+		item.result.Error = nil
+	}
+
+	for idx, item := range items {
+		if cp.Done(item.key) {
+			item.result.Status = ImportStatusSkipped
+			continue
+		}
+
+		if err := i.importItem(ctx, limit, item); err != nil {
+			if err == ctx.Err() {
+				abortImportItems(items[idx:], err)
+				return err
+			}
+
+			item.result.Status = ImportStatusFailed
+			item.result.Error = err
+			abortImportItems(items[idx+1:], ErrImportAborted)
+			return nil
+		}
+
+		item.result.Status = ImportStatusSucceeded
+		if err := cp.Mark(item.key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// importItem 导入单条消息，并对可重试的错误进行重试，上下文结束时返回上下文的错误
+func (i *Importer) importItem(ctx context.Context, limit *limiter.Limiter, item *importItem) (err error) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(i.opt.RetryInterval)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
+		}
+
+		if err = limit.WaitContext(ctx); err != nil {
+			return
+		}
+
+		item.result.Attempts++
+
+		if err = i.api.ImportMessage(item.message); err == nil {
+			return
+		}
+
+		if attempt >= i.opt.MaxRetries || !isRetryableImportError(err) {
+			return
+		}
+	}
+}
+
+// FailedResults 获取导入失败的结果
+func (r *ImportReport) FailedResults() (results []*ImportResult) {
+	for _, result := range r.Results {
+		if result.Status == ImportStatusFailed {
+			results = append(results, result)
+		}
+	}
+
+	return
+}
+
+func newImportItem(message *Message) *importItem {
+	result := &ImportResult{
+		FromUserId:   message.GetSender(),
+		MsgTimeStamp: message.GetTimestamp(),
+		MsgSeq:       message.GetSerialNo(),
+		MsgRandom:    message.GetRandom(),
+	}
+
+	if receivers := message.GetReceivers(); len(receivers) > 0 {
+		result.ToUserId = receivers[0]
+	}
+
+	return &importItem{
+		key:     fmt.Sprintf("%s|%s|%d|%d|%s", result.FromUserId, result.ToUserId, result.MsgTimeStamp, result.MsgSeq, bodyDigest(message.GetBody())),
+		message: message,
+		result:  result,
+	}
+}
+
+// isRetryableImportError 是否为可重试的错误，参数错误不重试
+func isRetryableImportError(err error) bool {
+	if e, ok := err.(core.Error); ok {
+		return e.Code() != enum.InvalidParamsCode
+	}
+
+	return true
+}
+
+// abortImportItems 将尚未导入的消息记为失败
+func abortImportItems(items []*importItem, err error) {
+	for _, item := range items {
+		if item.result.Status == "" {
+			item.result.Status = ImportStatusFailed
+			item.result.Error = err
+		}
+	}
+}
+
+// lessImportItem 按时间戳、序列号升序排列，相同时保持添加顺序
+func lessImportItem(a, b *importItem) bool {
+	if a.result.MsgTimeStamp != b.result.MsgTimeStamp {
+		return a.result.MsgTimeStamp < b.result.MsgTimeStamp
+	}
+
+	return a.result.MsgSeq < b.result.MsgSeq
+}
+
+// bodyDigest 消息内容的摘要
+func bodyDigest(body interface{}) string {
+	data, _ := json.Marshal(body)
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:8])
+}
+
+// sessionKey 会话标识，与消息方向无关
+func sessionKey(fromUserId, toUserId string) string {
+	if fromUserId > toUserId {
+		fromUserId, toUserId = toUserId, fromUserId
+	}
+
+	return fromUserId + "|" + toUserId
+}
@@ -0,0 +1,171 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/19 10:00
+ * @Desc: 单聊消息批量导入测试
+ */
+
+package private
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+type stubImportAPI struct {
+	API
+	mu       sync.Mutex
+	imported []string
+	fail     map[string]bool
+	attempts int
+}
+
+func (s *stubImportAPI) ImportMessage(message *Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	text := message.GetBody()[0].MsgContent.(MsgTextContent).Text
+	s.attempts++
+	if s.fail[text] {
+		return errors.New("import failed")
+	}
+
+	s.imported = append(s.imported, text)
+
+	return nil
+}
+
+func newImportMessage(timestamp int64, text string) *Message {
+	message := NewMessage()
+	message.SetSender("a")
+	message.SetReceivers("b")
+	message.SetTimestamp(timestamp)
+	message.SetContent(MsgTextContent{Text: text})
+
+	return message
+}
+
+func TestImporter_Dedup(t *testing.T) {
+	api := &stubImportAPI{}
+	importer := NewImporter(api, &ImporterOptions{Concurrency: 1})
+
+	// 未设置随机数的相同消息视为重复，内容不同的消息不视为重复
+	importer.Add(newImportMessage(2, "second"), newImportMessage(1, "first"), newImportMessage(2, "second"), newImportMessage(0, "no timestamp"))
+
+	report, err := importer.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Total != 4 || report.Succeeded != 2 || report.Duplicated != 1 || report.Failed != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	if len(api.imported) != 2 || api.imported[0] != "first" || api.imported[1] != "second" {
+		t.Fatalf("unexpected import order: %v", api.imported)
+	}
+
+	if failed := report.FailedResults(); len(failed) != 1 || failed[0].Error != errNotSetMsgTimestamp {
+		t.Fatalf("unexpected failed results: %+v", failed)
+	}
+}
+
+func TestImporter_Resume(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "import.checkpoint")
+
+	api := &stubImportAPI{fail: map[string]bool{"second": true}}
+	importer := NewImporter(api, &ImporterOptions{CheckpointFile: file, MaxRetries: -1})
+	importer.Add(newImportMessage(1, "first"), newImportMessage(2, "second"))
+
+	report, err := importer.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Succeeded != 1 || report.Failed != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	// 重新构建的消息未设置随机数，再次执行时仅导入上次失败的消息
+	api = &stubImportAPI{}
+	importer = NewImporter(api, &ImporterOptions{CheckpointFile: file})
+	importer.Add(newImportMessage(1, "first"), newImportMessage(2, "second"))
+
+	if report, err = importer.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Skipped != 1 || report.Succeeded != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	if len(api.imported) != 1 || api.imported[0] != "second" {
+		t.Fatalf("unexpected imported messages: %v", api.imported)
+	}
+}
+
+func TestImporter_AbortSession(t *testing.T) {
+	api := &stubImportAPI{fail: map[string]bool{"second": true}}
+	importer := NewImporter(api, &ImporterOptions{MaxRetries: 2, RetryInterval: time.Millisecond})
+
+	// 其他会话的消息不受影响
+	other := newImportMessage(1, "other")
+	other.SetReceivers("c")
+	importer.Add(newImportMessage(1, "first"), newImportMessage(2, "second"), newImportMessage(3, "third"), other)
+
+	report, err := importer.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Succeeded != 2 || report.Failed != 2 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	results := make(map[int64]*ImportResult)
+	for _, result := range report.Results {
+		if result.ToUserId == "b" {
+			results[result.MsgTimeStamp] = result
+		}
+	}
+
+	if results[2].Attempts != 3 || results[2].Error == nil || results[2].Error == ErrImportAborted {
+		t.Fatalf("unexpected failed result: %+v", results[2])
+	}
+
+	if results[3].Status != ImportStatusFailed || results[3].Error != ErrImportAborted || results[3].Attempts != 0 {
+		t.Fatalf("unexpected aborted result: %+v", results[3])
+	}
+}
+
+func TestImporter_RunContext(t *testing.T) {
+	api := &stubImportAPI{fail: map[string]bool{"first": true}}
+	importer := NewImporter(api, &ImporterOptions{RetryInterval: time.Hour})
+	importer.Add(newImportMessage(1, "first"), newImportMessage(2, "second"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	report, err := importer.RunContext(ctx)
+	if err != context.DeadlineExceeded {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.Failed != 2 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	for _, result := range report.Results {
+		if result.Error != context.DeadlineExceeded {
+			t.Fatalf("unexpected result: %+v", result)
+		}
+	}
+
+	if api.attempts != 1 {
+		t.Fatalf("unexpected attempts: %d", api.attempts)
+	}
+}
@@ -25,6 +25,7 @@ type Message struct {
 	customData       interface{}     // 自定义数据
 	sendControls     map[string]bool // 发送消息控制
 	callbackControls map[string]bool // 禁用回调
+	supportExtension bool            // 支持消息扩展
 }
 
 func NewMessage() *Message {
@@ -142,6 +143,20 @@ func (m *Message) SetNoLastMsg() {
 	m.sendControls["NoLastMsg"] = true
 }
 
+// SetSupportMessageExtension 设置该条消息支持消息扩展
+func (m *Message) SetSupportMessageExtension() {
+	m.supportExtension = true
+}
+
+// GetSupportMessageExtension 获取消息扩展开关（1表示支持；0表示不支持）
+func (m *Message) GetSupportMessageExtension() int {
+	if m.supportExtension {
+		return 1
+	}
+
+	return 0
+}
+
 // GetSendMsgControl 获取消息发送控制选项
 func (m *Message) GetSendMsgControl() (controls []string) {
 	if m.sendControls != nil {
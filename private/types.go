@@ -12,18 +12,19 @@ import "github.com/default-yarns/tencent-im/internal/types"
 type (
 	// 发送消息（请求）
 	sendMessageReq struct {
-		FromUserId            string                 `json:"From_Account,omitempty"`          // （选填）消息发送方UserID（用于指定发送消息方帐号）
-		ToUserId              string                 `json:"To_Account"`                      // （必填）消息接收方UserID
-		MsgLifeTime           int                    `json:"MsgLifeTime,omitempty"`           // （选填）消息离线保存时长（单位：秒），最长为7天（604800秒）
-		MsgSeq                int                    `json:"MsgSeq,omitempty"`                // （选填）消息序列号，后台会根据该字段去重及进行同秒内消息的排序，详细规则请看本接口的功能说明。若不填该字段，则由后台填入随机数。
-		MsgRandom             uint32                 `json:"MsgRandom"`                       // （必填）消息随机数，后台用于同一秒内的消息去重。请确保该字段填的是随机数
-		MsgTimeStamp          int64                  `json:"MsgTimeStamp,omitempty"`          // （选填）消息时间戳，UNIX 时间戳（单位：秒）
-		MsgBody               []*types.MsgBody       `json:"MsgBody"`                         // （必填）消息内容，具体格式请参考 消息格式描述（注意，一条消息可包括多种消息元素，MsgBody 为 Array 类型）
-		SyncOtherMachine      int                    `json:"SyncOtherMachine,omitempty"`      // （选填）消息是否同步到在线终端和漫游上 1：把消息同步到 From_Account 在线终端和漫游上；2：消息不同步至 From_Account； 若不填写默认情况下会将消息存 From_Account 漫游
-		CloudCustomData       string                 `json:"CloudCustomData,omitempty"`       // （选填）消息回调禁止开关，只对本条消息有效，
-		SendMsgControl        []string               `json:"SendMsgControl,omitempty"`        // （选填）消息发送控制选项，是一个 String 数组，只对本条消息有效。
-		ForbidCallbackControl []string               `json:"ForbidCallbackControl,omitempty"` // （选填）消息回调禁止开关，只对本条消息有效
-		OfflinePushInfo       *types.OfflinePushInfo `json:"OfflinePushInfo,omitempty"`       // （选填）离线推送信息配置
+		FromUserId            string                 `json:"From_Account,omitempty"`            // （选填）消息发送方UserID（用于指定发送消息方帐号）
+		ToUserId              string                 `json:"To_Account"`                        // （必填）消息接收方UserID
+		MsgLifeTime           int                    `json:"MsgLifeTime,omitempty"`             // （选填）消息离线保存时长（单位：秒），最长为7天（604800秒）
+		MsgSeq                int                    `json:"MsgSeq,omitempty"`                  // （选填）消息序列号，后台会根据该字段去重及进行同秒内消息的排序，详细规则请看本接口的功能说明。若不填该字段，则由后台填入随机数。
+		MsgRandom             uint32                 `json:"MsgRandom"`                         // （必填）消息随机数，后台用于同一秒内的消息去重。请确保该字段填的是随机数
+		MsgTimeStamp          int64                  `json:"MsgTimeStamp,omitempty"`            // （选填）消息时间戳，UNIX 时间戳（单位：秒）
+		MsgBody               []*types.MsgBody       `json:"MsgBody"`                           // （必填）消息内容，具体格式请参考 消息格式描述（注意，一条消息可包括多种消息元素，MsgBody 为 Array 类型）
+		SyncOtherMachine      int                    `json:"SyncOtherMachine,omitempty"`        // （选填）消息是否同步到在线终端和漫游上 1：把消息同步到 From_Account 在线终端和漫游上；2：消息不同步至 From_Account； 若不填写默认情况下会将消息存 From_Account 漫游
+		CloudCustomData       string                 `json:"CloudCustomData,omitempty"`         // （选填）消息回调禁止开关，只对本条消息有效，
+		SendMsgControl        []string               `json:"SendMsgControl,omitempty"`          // （选填）消息发送控制选项，是一个 String 数组，只对本条消息有效。
+		ForbidCallbackControl []string               `json:"ForbidCallbackControl,omitempty"`   // （选填）消息回调禁止开关，只对本条消息有效
+		OfflinePushInfo       *types.OfflinePushInfo `json:"OfflinePushInfo,omitempty"`         // （选填）离线推送信息配置
+		SupportExtension      int                    `json:"SupportMessageExtension,omitempty"` // （选填）该条消息是否支持消息扩展，0为不支持，1为支持
 	}
 
 	// 发送消息（响应）
@@ -141,6 +142,31 @@ type (
 		MsgKey     string `json:"MsgKey"`       // （必填）待撤回消息的唯一标识。该字段由 REST API 接口 单发单聊消息 和 批量发单聊消息 返回
 	}
 
+	// 单向删除单聊消息（请求）
+	deleteMessagesReq struct {
+		UserId     string   `json:"Operator_Account"` // （必填）删除消息的一方UserID，仅删除该用户一侧的漫游消息
+		PeerUserId string   `json:"Peer_Account"`     // （必填）会话对方的UserID
+		MsgKeys    []string `json:"MsgKeyList"`       // （必填）待删除消息的唯一标识列表
+	}
+
+	// 单向删除单聊消息（响应）
+	deleteMessagesResp struct {
+		types.ActionBaseResp
+		Results []deleteMessageResult `json:"ResultItem"` // 删除结果列表
+	}
+
+	// 删除单聊消息结果
+	deleteMessageResult struct {
+		MsgKey  string `json:"MsgKey"`  // 消息的唯一标识
+		RetCode int    `json:"RetCode"` // 单个消息的删除结果：0表示成功；其它表示失败
+	}
+
+	// 单向清空单聊历史消息（请求）
+	clearMessagesReq struct {
+		UserId     string `json:"Operator_Account"` // （必填）清空消息的一方UserID，仅清空该用户一侧的漫游消息
+		PeerUserId string `json:"Peer_Account"`     // （必填）会话对方的UserID
+	}
+
 	// 设置单聊消息已读（请求）
 	setMessageReadReq struct {
 		UserId     string `json:"Report_Account"` // （必填）进行消息已读的用户UserId
@@ -180,13 +206,56 @@ type (
 		Errors  []*UnreadMessageError // 错误消息列表
 	}
 
-	ImageInfo          = types.ImageInfo
-	MsgTextContent     = types.MsgTextContent
-	MsgFaceContent     = types.MsgFaceContent
-	MsgFileContent     = types.MsgFileContent
-	MsgImageContent    = types.MsgImageContent
-	MsgSoundContent    = types.MsgSoundContent
-	MsgVideoContent    = types.MsgVideoContent
-	MsgCustomContent   = types.MsgCustomContent
-	MsgLocationContent = types.MsgLocationContent
+	// 设置单聊消息扩展（请求）
+	setMessageExtensionsReq struct {
+		FromUserId    string              `json:"From_Account"`            // （必填）消息发送方UserID
+		ToUserId      string              `json:"To_Account"`              // （必填）消息接收方UserID
+		MsgKey        string              `json:"MsgKey"`                  // （必填）消息的唯一标识
+		OperateType   int                 `json:"OperateType"`             // （必填）操作类型：1表示设置；2表示删除；3表示清空
+		ExtensionList []*MessageExtension `json:"ExtensionList,omitempty"` // （选填）扩展项列表，清空时不需要填写
+	}
+
+	// 设置单聊消息扩展（响应）
+	setMessageExtensionsResp struct {
+		types.ActionBaseResp
+		Results []MessageExtensionResult `json:"ExtensionList"` // 扩展项操作结果
+	}
+
+	// 获取单聊消息扩展（请求）
+	fetchMessageExtensionsReq struct {
+		FromUserId string `json:"From_Account"`       // （必填）消息发送方UserID
+		ToUserId   string `json:"To_Account"`         // （必填）消息接收方UserID
+		MsgKey     string `json:"MsgKey"`             // （必填）消息的唯一标识
+		StartSeq   int    `json:"StartSeq,omitempty"` // （选填）拉取的起始版本号，第一次拉取时不填
+	}
+
+	// 获取单聊消息扩展（响应）
+	fetchMessageExtensionsResp struct {
+		types.ActionBaseResp
+		Complete      int                 `json:"Complete"`      // 是否全部拉取，0表示未全部拉取，需要续拉，1表示已全部拉取
+		LatestSeq     int                 `json:"LatestSeq"`     // 当前最新的扩展版本号
+		ClearSeq      int                 `json:"ClearSeq"`      // 最近一次清空扩展时的版本号
+		ExtensionList []*MessageExtension `json:"ExtensionList"` // 扩展项列表
+	}
+
+	// FetchMessageExtensionsRet 获取单聊消息扩展（返回）
+	FetchMessageExtensionsRet struct {
+		LatestSeq int                 // 当前最新的扩展版本号
+		ClearSeq  int                 // 最近一次清空扩展时的版本号
+		NextSeq   int                 // 下一次拉取的起始版本号
+		HasMore   bool                // 是否还有更多数据
+		List      []*MessageExtension // 扩展项列表
+	}
+
+	MessageExtension       = types.MsgExtension
+	MessageExtensionResult = types.MsgExtensionResult
+	ImageInfo              = types.ImageInfo
+	MsgTextContent         = types.MsgTextContent
+	MsgFaceContent         = types.MsgFaceContent
+	MsgFileContent         = types.MsgFileContent
+	MsgImageContent        = types.MsgImageContent
+	MsgSoundContent        = types.MsgSoundContent
+	MsgVideoContent        = types.MsgVideoContent
+	MsgCustomContent       = types.MsgCustomContent
+	MsgLocationContent     = types.MsgLocationContent
 )
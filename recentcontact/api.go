@@ -9,6 +9,7 @@ package recentcontact
 
 import (
 	"github.com/default-yarns/tencent-im/internal/core"
+	"github.com/default-yarns/tencent-im/internal/enum"
 	"github.com/default-yarns/tencent-im/internal/types"
 )
 
@@ -16,8 +17,17 @@ const (
 	service              = "recentcontact"
 	commandFetchSessions = "get_list"
 	commandDeleteSession = "delete"
+	commandCreateGroup   = "create_contact_group"
+	commandUpdateGroup   = "update_contact_group"
+	commandDeleteGroup   = "del_contact_group"
+	commandFetchGroups   = "get_contact_group"
+	commandMarkSession   = "mark_contact"
+	commandGetMarks      = "get_contact_mark"
 )
 
+// API 最近联系人接口
+// 注意：即时通信 IM 服务端 REST API 未提供会话置顶（Pin/Unpin）接口，会话置顶仅能通过客户端 SDK 设置，
+// 服务端只能通过拉取会话列表读取置顶状态（参见 SessionItem.IsPinned）
 type API interface {
 	// FetchSessions 拉取会话列表
 	// 支持分页拉取会话列表
@@ -37,6 +47,60 @@ type API interface {
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/62119
 	DeleteSession(fromUserId, toUserId string, SessionType SessionType, isClearRamble ...bool) (err error)
+
+	// CreateSessionGroup 创建会话分组
+	// 为指定用户创建会话分组，创建时可同时向分组内添加会话。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/85790
+	CreateSessionGroup(userId, groupName string, contacts ...*Contact) (groupId int, err error)
+
+	// RenameSessionGroup 重命名会话分组
+	// 本方法由“更新会话分组（update_contact_group）”拓展而来
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/85791
+	RenameSessionGroup(userId, oldGroupName, newGroupName string) (err error)
+
+	// AddSessionsToGroup 添加会话到会话分组
+	// 本方法由“更新会话分组（update_contact_group）”拓展而来
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/85791
+	AddSessionsToGroup(userId, groupName string, contacts ...*Contact) (err error)
+
+	// RemoveSessionsFromGroup 从会话分组中移除会话
+	// 本方法由“更新会话分组（update_contact_group）”拓展而来
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/85791
+	RemoveSessionsFromGroup(userId, groupName string, contacts ...*Contact) (err error)
+
+	// DeleteSessionGroups 删除会话分组
+	// 删除指定用户的会话分组，分组内的会话不会被删除。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/85792
+	DeleteSessionGroups(userId string, groupNames ...string) (err error)
+
+	// FetchSessionGroups 拉取会话分组
+	// 支持分页拉取指定用户的会话分组及分组内的会话
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/85793
+	FetchSessionGroups(userId string, startIndex int) (ret *FetchSessionGroupsRet, err error)
+
+	// PullSessionGroups 续拉取会话分组
+	// 本API是借助"拉取会话分组"API进行扩展实现
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/85793
+	PullSessionGroups(userId string, fn func(ret *FetchSessionGroupsRet)) (err error)
+
+	// MarkSessions 设置会话标记
+	// 为指定用户的会话设置或取消标准标记（星标、未读、折叠、隐藏），以及设置自定义标记。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/85794
+	MarkSessions(userId string, args ...*SessionMarkArg) (results []*SessionMarkResult, err error)
+
+	// GetSessionMarks 获取会话标记
+	// 查询指定用户的会话标记
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/85795
+	GetSessionMarks(userId string, contacts ...*Contact) (results []*SessionMarkResult, err error)
 }
 
 type api struct {
@@ -146,3 +210,227 @@ func (a *api) DeleteSession(fromUserId, toUserId string, SessionType SessionType
 
 	return
 }
+
+// CreateSessionGroup 创建会话分组
+// 为指定用户创建会话分组，创建时可同时向分组内添加会话。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/85790
+func (a *api) CreateSessionGroup(userId, groupName string, contacts ...*Contact) (groupId int, err error) {
+	req := &createSessionGroupReq{
+		UserId: userId,
+		Groups: []*createSessionGroupItem{{GroupName: groupName, Contacts: contacts}},
+	}
+	resp := &createSessionGroupResp{}
+
+	if err = a.client.Post(service, commandCreateGroup, req, resp); err != nil {
+		return
+	}
+
+	for _, item := range resp.Results {
+		if item.ResultCode != enum.SuccessCode {
+			err = core.NewError(item.ResultCode, "session group create failed")
+			return
+		}
+
+		if item.Group.GroupName == groupName {
+			groupId = item.Group.GroupId
+		}
+	}
+
+	return
+}
+
+// RenameSessionGroup 重命名会话分组
+// 本方法由“更新会话分组（update_contact_group）”拓展而来
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/85791
+func (a *api) RenameSessionGroup(userId, oldGroupName, newGroupName string) (err error) {
+	return a.updateSessionGroup(userId, sessionGroupUpdateName, &updateSessionGroupItem{
+		OldGroupName: oldGroupName,
+		NewGroupName: newGroupName,
+	})
+}
+
+// AddSessionsToGroup 添加会话到会话分组
+// 本方法由“更新会话分组（update_contact_group）”拓展而来
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/85791
+func (a *api) AddSessionsToGroup(userId, groupName string, contacts ...*Contact) (err error) {
+	return a.updateSessionGroupMembers(userId, groupName, sessionOperateAdd, contacts...)
+}
+
+// RemoveSessionsFromGroup 从会话分组中移除会话
+// 本方法由“更新会话分组（update_contact_group）”拓展而来
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/85791
+func (a *api) RemoveSessionsFromGroup(userId, groupName string, contacts ...*Contact) (err error) {
+	return a.updateSessionGroupMembers(userId, groupName, sessionOperateDelete, contacts...)
+}
+
+// updateSessionGroupMembers 更新会话分组内的会话
+func (a *api) updateSessionGroupMembers(userId, groupName string, operateType sessionOperateType, contacts ...*Contact) (err error) {
+	if len(contacts) == 0 {
+		err = core.NewError(enum.InvalidParamsCode, "the contacts is not set")
+		return
+	}
+
+	group := &updateSessionGroupItem{
+		OldGroupName: groupName,
+		Contacts:     make([]*updateSessionContact, 0, len(contacts)),
+	}
+
+	for _, contact := range contacts {
+		group.Contacts = append(group.Contacts, &updateSessionContact{
+			OperateType: operateType,
+			Contact:     contact,
+		})
+	}
+
+	return a.updateSessionGroup(userId, sessionGroupUpdateMembers, group)
+}
+
+// updateSessionGroup 更新会话分组
+func (a *api) updateSessionGroup(userId string, updateType sessionGroupUpdateType, group *updateSessionGroupItem) (err error) {
+	req := &updateSessionGroupReq{
+		UserId:     userId,
+		UpdateType: updateType,
+		Group:      group,
+	}
+
+	if err = a.client.Post(service, commandUpdateGroup, req, &types.ActionBaseResp{}); err != nil {
+		return
+	}
+
+	return
+}
+
+// DeleteSessionGroups 删除会话分组
+// 删除指定用户的会话分组，分组内的会话不会被删除。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/85792
+func (a *api) DeleteSessionGroups(userId string, groupNames ...string) (err error) {
+	if len(groupNames) == 0 {
+		err = core.NewError(enum.InvalidParamsCode, "the group names is not set")
+		return
+	}
+
+	req := &deleteSessionGroupsReq{UserId: userId, GroupNames: groupNames}
+
+	if err = a.client.Post(service, commandDeleteGroup, req, &types.ActionBaseResp{}); err != nil {
+		return
+	}
+
+	return
+}
+
+// FetchSessionGroups 拉取会话分组
+// 支持分页拉取指定用户的会话分组及分组内的会话
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/85793
+func (a *api) FetchSessionGroups(userId string, startIndex int) (ret *FetchSessionGroupsRet, err error) {
+	req := &fetchSessionGroupsReq{UserId: userId, StartIndex: startIndex}
+	resp := &fetchSessionGroupsResp{}
+
+	if err = a.client.Post(service, commandFetchGroups, req, resp); err != nil {
+		return
+	}
+
+	ret = &FetchSessionGroupsRet{
+		Next:     resp.NextStartIndex,
+		HasMore:  resp.CompleteFlag == 0,
+		Groups:   resp.Groups,
+		Contacts: resp.Contacts,
+	}
+
+	return
+}
+
+// PullSessionGroups 续拉取会话分组
+// 本API是借助"拉取会话分组"API进行扩展实现
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/85793
+func (a *api) PullSessionGroups(userId string, fn func(ret *FetchSessionGroupsRet)) (err error) {
+	var (
+		ret        *FetchSessionGroupsRet
+		startIndex int
+	)
+
+	for ret == nil || ret.HasMore {
+		ret, err = a.FetchSessionGroups(userId, startIndex)
+		if err != nil {
+			return
+		}
+
+		fn(ret)
+
+		if ret.HasMore {
+			startIndex = ret.Next
+		}
+	}
+
+	return
+}
+
+// MarkSessions 设置会话标记
+// 为指定用户的会话设置或取消标准标记（星标、未读、折叠、隐藏），以及设置自定义标记。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/85794
+func (a *api) MarkSessions(userId string, args ...*SessionMarkArg) (results []*SessionMarkResult, err error) {
+	req := &markSessionsReq{UserId: userId, Items: make([]*markSessionItem, 0, len(args))}
+
+	for _, arg := range args {
+		if len(arg.SetMarks) > 0 || len(arg.UnsetMarks) > 0 {
+			req.Items = append(req.Items, &markSessionItem{
+				OperateType: 1,
+				Contact:     arg.Contact,
+				SetMarks:    arg.SetMarks,
+				UnsetMarks:  arg.UnsetMarks,
+			})
+		}
+
+		if arg.CustomMark != nil {
+			req.Items = append(req.Items, &markSessionItem{
+				OperateType: 2,
+				Contact:     arg.Contact,
+				CustomMark:  arg.CustomMark,
+			})
+		}
+	}
+
+	if len(req.Items) == 0 {
+		err = core.NewError(enum.InvalidParamsCode, "the session marks is not set")
+		return
+	}
+
+	resp := &markSessionsResp{}
+
+	if err = a.client.Post(service, commandMarkSession, req, resp); err != nil {
+		return
+	}
+
+	results = resp.Results
+
+	return
+}
+
+// GetSessionMarks 获取会话标记
+// 查询指定用户的会话标记
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/85795
+func (a *api) GetSessionMarks(userId string, contacts ...*Contact) (results []*SessionMarkResult, err error) {
+	if len(contacts) == 0 {
+		err = core.NewError(enum.InvalidParamsCode, "the contacts is not set")
+		return
+	}
+
+	req := &getSessionMarksReq{UserId: userId, Contacts: contacts}
+	resp := &getSessionMarksResp{}
+
+	if err = a.client.Post(service, commandGetMarks, req, resp); err != nil {
+		return
+	}
+
+	results = resp.Results
+
+	return
+}
@@ -14,3 +14,29 @@ const (
 	SessionTypeC2C SessionType = 1 // C2C 会话
 	SessionTypeG2C SessionType = 2 // G2C 会话
 )
+
+// SessionMark 会话标准标记（按位表示）
+type SessionMark uint
+
+const (
+	SessionMarkStar   SessionMark = 0 // 星标会话
+	SessionMarkUnread SessionMark = 1 // 标记未读
+	SessionMarkFold   SessionMark = 2 // 折叠会话
+	SessionMarkHide   SessionMark = 3 // 隐藏会话
+)
+
+// sessionGroupUpdateType 会话分组更新类型
+type sessionGroupUpdateType int
+
+const (
+	sessionGroupUpdateName    sessionGroupUpdateType = 1 // 更新分组名
+	sessionGroupUpdateMembers sessionGroupUpdateType = 2 // 更新分组内的会话
+)
+
+// sessionOperateType 会话操作类型
+type sessionOperateType int
+
+const (
+	sessionOperateAdd    sessionOperateType = 1 // 添加
+	sessionOperateDelete sessionOperateType = 2 // 删除
+)
@@ -62,11 +62,183 @@ type fetchSessionsResp struct {
 
 // SessionItem 会话对象
 type SessionItem struct {
+	Type            SessionType `json:"Type"`                     // 会话类型：1 表示 C2C 会话；2 表示 G2C 会话
+	UserId          string      `json:"To_Account,omitempty"`     // C2C 会话才会返回，返回会话方的 UserID
+	GroupId         string      `json:"GroupId,omitempty"`        // G2C 会话才会返回，返回群 ID
+	MsgTime         int         `json:"MsgTime"`                  // 会话时间
+	TopFlag         int         `json:"TopFlag"`                  // 置顶标记：0 标识普通会话；1 标识置顶会话
+	StandardMark    uint64      `json:"StandardMark,omitempty"`   // 会话标准标记，按位表示，参见 SessionMark
+	CustomMark      string      `json:"CustomMark,omitempty"`     // 会话自定义标记
+	ContactGroupIds []int       `json:"ContactGroupId,omitempty"` // 会话所属的会话分组ID列表
+}
+
+// IsPinned 是否为置顶会话，置顶状态为只读，服务端 REST API 不支持设置
+func (s *SessionItem) IsPinned() bool {
+	return s.TopFlag == 1
+}
+
+// HasMark 是否设置了指定的标准标记
+func (s *SessionItem) HasMark(mark SessionMark) bool {
+	return s.StandardMark&(1<<mark) != 0
+}
+
+// InGroup 是否属于指定的会话分组
+func (s *SessionItem) InGroup(groupId int) bool {
+	for _, id := range s.ContactGroupIds {
+		if id == groupId {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Contact 会话标识
+type Contact struct {
 	Type    SessionType `json:"Type"`                 // 会话类型：1 表示 C2C 会话；2 表示 G2C 会话
-	UserId  string      `json:"To_Account,omitempty"` // C2C 会话才会返回，返回会话方的 UserID
-	GroupId string      `json:"GroupId,omitempty"`    // G2C 会话才会返回，返回群 ID
-	MsgTime int         `json:"MsgTime"`              // 会话时间
-	TopFlag int         `json:"TopFlag"`              // 置顶标记：0 标识普通会话；1 标识置顶会话
+	UserId  string      `json:"To_Account,omitempty"` // C2C 会话的对方 UserID
+	GroupId string      `json:"ToGroupId,omitempty"`  // G2C 会话的群 ID
+}
+
+// NewC2CContact 创建 C2C 会话标识
+func NewC2CContact(userId string) *Contact {
+	return &Contact{Type: SessionTypeC2C, UserId: userId}
+}
+
+// NewG2CContact 创建 G2C 会话标识
+func NewG2CContact(groupId string) *Contact {
+	return &Contact{Type: SessionTypeG2C, GroupId: groupId}
+}
+
+// SessionGroup 会话分组
+type SessionGroup struct {
+	GroupName string `json:"GroupName"` // 会话分组名
+	GroupId   int    `json:"GroupId"`   // 会话分组ID
+}
+
+// SessionMarkArg 设置会话标记（参数）
+type SessionMarkArg struct {
+	Contact    *Contact      // （必填）会话标识
+	SetMarks   []SessionMark // （选填）需要设置的标准标记
+	UnsetMarks []SessionMark // （选填）需要取消的标准标记
+	CustomMark *string       // （选填）自定义标记，为nil时不修改
+}
+
+// SessionMarkResult 会话标记结果
+type SessionMarkResult struct {
+	Contact      *Contact `json:"ContactItem"`            // 会话标识
+	ResultCode   int      `json:"ResultCode"`             // 结果码：0表示成功；其它表示失败
+	ResultInfo   string   `json:"ResultInfo,omitempty"`   // 结果信息
+	StandardMark uint64   `json:"StandardMark,omitempty"` // 会话标准标记，按位表示，参见 SessionMark
+	CustomMark   string   `json:"CustomMark,omitempty"`   // 会话自定义标记
+}
+
+// HasMark 是否设置了指定的标准标记
+func (r *SessionMarkResult) HasMark(mark SessionMark) bool {
+	return r.StandardMark&(1<<mark) != 0
+}
+
+// FetchSessionGroupsRet 拉取会话分组（返回）
+type FetchSessionGroupsRet struct {
+	Next     int            // 下一页拉取的起始位置
+	HasMore  bool           // 是否还有更多数据
+	Groups   []SessionGroup // 会话分组列表
+	Contacts []*SessionItem // 会话分组内的会话列表
+}
+
+// createSessionGroupReq 创建会话分组（请求）
+type createSessionGroupReq struct {
+	UserId string                    `json:"From_Account"`     // （必填）会话分组所属的 UserID
+	Groups []*createSessionGroupItem `json:"GroupContactItem"` // （必填）待创建的会话分组
+}
+
+// createSessionGroupItem 待创建的会话分组
+type createSessionGroupItem struct {
+	GroupName string     `json:"GroupName"`             // （必填）会话分组名
+	Contacts  []*Contact `json:"ContactItem,omitempty"` // （选填）分组内的会话
+}
+
+// createSessionGroupResp 创建会话分组（响应）
+type createSessionGroupResp struct {
+	types.ActionBaseResp
+	Results []struct {
+		Group      SessionGroup `json:"GroupItem"`  // 会话分组
+		ResultCode int          `json:"ResultCode"` // 结果码：0表示成功；其它表示失败
+	} `json:"GroupResultItem"`
+}
+
+// updateSessionGroupReq 更新会话分组（请求）
+type updateSessionGroupReq struct {
+	UserId     string                  `json:"From_Account"` // （必填）会话分组所属的 UserID
+	UpdateType sessionGroupUpdateType  `json:"UpdateType"`   // （必填）更新类型：1 表示更新分组名；2 表示更新分组内的会话
+	Group      *updateSessionGroupItem `json:"UpdateGroup"`  // （必填）待更新的会话分组
+}
+
+// updateSessionGroupItem 待更新的会话分组
+type updateSessionGroupItem struct {
+	OldGroupName string                  `json:"OldGroupName"`                // （必填）原会话分组名
+	NewGroupName string                  `json:"NewGroupName,omitempty"`      // （选填）新会话分组名，更新分组名时必填
+	Contacts     []*updateSessionContact `json:"ContactUpdateItem,omitempty"` // （选填）更新的会话，更新分组内的会话时必填
+}
+
+// updateSessionContact 更新的会话
+type updateSessionContact struct {
+	OperateType sessionOperateType `json:"ContactOpType"` // （必填）操作类型：1 表示添加；2 表示删除
+	Contact     *Contact           `json:"ContactItem"`   // （必填）会话标识
+}
+
+// deleteSessionGroupsReq 删除会话分组（请求）
+type deleteSessionGroupsReq struct {
+	UserId     string   `json:"From_Account"` // （必填）会话分组所属的 UserID
+	GroupNames []string `json:"GroupName"`    // （必填）待删除的会话分组名
+}
+
+// fetchSessionGroupsReq 拉取会话分组（请求）
+type fetchSessionGroupsReq struct {
+	UserId     string `json:"From_Account"` // （必填）会话分组所属的 UserID
+	StartIndex int    `json:"StartIndex"`   // （必填）拉取的起始位置，第一页填 0
+}
+
+// fetchSessionGroupsResp 拉取会话分组（响应）
+type fetchSessionGroupsResp struct {
+	types.ActionBaseResp
+	CompleteFlag   int            `json:"CompleteFlag"`   // 结束标识：1 表示已全部返回，0 表示还有数据没拉完
+	NextStartIndex int            `json:"NextStartIndex"` // 下一页拉取的起始位置
+	Groups         []SessionGroup `json:"GroupItem"`      // 会话分组列表
+	Contacts       []*SessionItem `json:"ContactItem"`    // 会话分组内的会话列表
+}
+
+// markSessionsReq 设置会话标记（请求）
+type markSessionsReq struct {
+	UserId string             `json:"From_Account"` // （必填）会话所属的 UserID
+	Items  []*markSessionItem `json:"MarkItem"`     // （必填）待设置标记的会话
+}
+
+// markSessionItem 待设置标记的会话
+type markSessionItem struct {
+	OperateType int           `json:"OperationType"`        // （必填）操作类型：1 表示设置标准标记；2 表示设置自定义标记
+	Contact     *Contact      `json:"ContactItem"`          // （必填）会话标识
+	SetMarks    []SessionMark `json:"SetMark,omitempty"`    // （选填）需要设置的标准标记
+	UnsetMarks  []SessionMark `json:"UnsetMark,omitempty"`  // （选填）需要取消的标准标记
+	CustomMark  *string       `json:"CustomMark,omitempty"` // （选填）自定义标记
+}
+
+// markSessionsResp 设置会话标记（响应）
+type markSessionsResp struct {
+	types.ActionBaseResp
+	Results []*SessionMarkResult `json:"ResultItem"` // 设置结果
+}
+
+// getSessionMarksReq 获取会话标记（请求）
+type getSessionMarksReq struct {
+	UserId   string     `json:"From_Account"` // （必填）会话所属的 UserID
+	Contacts []*Contact `json:"ContactItem"`  // （必填）待查询的会话
+}
+
+// getSessionMarksResp 获取会话标记（响应）
+type getSessionMarksResp struct {
+	types.ActionBaseResp
+	Results []*SessionMarkResult `json:"ResultItem"` // 查询结果
 }
 
 // deleteSessionReq 删除单个会话（请求）
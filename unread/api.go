@@ -0,0 +1,249 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 10:05
+ * @Desc: 未读消息汇总
+ */
+
+package unread
+
+import (
+	"sort"
+
+	"github.com/default-yarns/tencent-im/group"
+	"github.com/default-yarns/tencent-im/internal/core"
+	"github.com/default-yarns/tencent-im/private"
+	"github.com/default-yarns/tencent-im/recentcontact"
+)
+
+const (
+	batchGetC2CUnreadLimit = 10 // 批量查询单聊未读消息计数限制
+)
+
+type API interface {
+	// GetSummary 获取用户未读消息汇总
+	// 本方法由“拉取会话列表（recentcontact.PullSessions）”、“查询单聊未读消息计数（private.GetUnreadMessageNum）”
+	// 以及“拉取用户所加入的群组（group.PullMemberGroups）”组合而来，返回用户的未读消息总数以及每个会话的未读消息数。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/62118
+	// https://cloud.tencent.com/document/product/269/56043
+	// https://cloud.tencent.com/document/product/269/1625
+	GetSummary(userId string) (ret *Summary, err error)
+
+	// MarkAllRead 标记用户的所有会话已读
+	// 本方法由“获取用户未读消息汇总（GetSummary）”拓展而来
+	// 单聊会话通过“设置单聊消息已读（private.SetMessageRead）”清除未读，群聊会话通过“设置成员未读消息计数（group.SetMemberUnreadMsgNum）”清除未读。
+	// 单个会话清除失败时继续处理其余会话，全部处理完成后返回 *MarkAllReadError，列出清除失败的会话。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/50349
+	// https://cloud.tencent.com/document/product/269/1637
+	MarkAllRead(userId string) (err error)
+}
+
+type api struct {
+	private       private.API
+	group         group.API
+	recentcontact recentcontact.API
+}
+
+func NewAPI(client core.Client) API {
+	return &api{
+		private:       private.NewAPI(client),
+		group:         group.NewAPI(client),
+		recentcontact: recentcontact.NewAPI(client),
+	}
+}
+
+// GetSummary 获取用户未读消息汇总
+// 本方法由“拉取会话列表（recentcontact.PullSessions）”、“查询单聊未读消息计数（private.GetUnreadMessageNum）”
+// 以及“拉取用户所加入的群组（group.PullMemberGroups）”组合而来，返回用户的未读消息总数以及每个会话的未读消息数。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/62118
+// https://cloud.tencent.com/document/product/269/56043
+// https://cloud.tencent.com/document/product/269/1625
+func (a *api) GetSummary(userId string) (ret *Summary, err error) {
+	var (
+		sessions     []*recentcontact.SessionItem
+		peerUserIds  []string
+		c2cUnreads   = make(map[string]int)
+		groupUnreads = make(map[string]int)
+	)
+
+	err = a.recentcontact.PullSessions(&recentcontact.PullSessionsArg{
+		UserId:                  userId,
+		IsAllowTopSession:       true,
+		IsAllowTopSessionPaging: true,
+	}, func(ret *recentcontact.FetchSessionsRet) {
+		sessions = append(sessions, ret.List...)
+	})
+	if err != nil {
+		return
+	}
+
+	for _, session := range sessions {
+		if session.Type == recentcontact.SessionTypeC2C {
+			peerUserIds = append(peerUserIds, session.UserId)
+		}
+	}
+
+	ret = &Summary{Sessions: make([]*SessionUnread, 0, len(sessions))}
+
+	if ret.C2CTotal, err = a.fetchC2CUnreads(userId, peerUserIds, c2cUnreads); err != nil {
+		return
+	}
+
+	if ret.GroupTotal, err = a.fetchGroupUnreads(userId, groupUnreads); err != nil {
+		return
+	}
+
+	ret.Total = ret.C2CTotal + ret.GroupTotal
+
+	for _, session := range sessions {
+		item := &SessionUnread{
+			Type:     session.Type,
+			UserId:   session.UserId,
+			GroupId:  session.GroupId,
+			MsgTime:  session.MsgTime,
+			IsPinned: session.IsPinned(),
+		}
+
+		switch session.Type {
+		case recentcontact.SessionTypeC2C:
+			item.UnreadMsgNum = c2cUnreads[session.UserId]
+		case recentcontact.SessionTypeG2C:
+			item.UnreadMsgNum = groupUnreads[session.GroupId]
+		}
+
+		ret.Sessions = append(ret.Sessions, item)
+	}
+
+	return
+}
+
+// MarkAllRead 标记用户的所有会话已读
+// 本方法由“获取用户未读消息汇总（GetSummary）”拓展而来
+// 单聊会话通过“设置单聊消息已读（private.SetMessageRead）”清除未读，群聊会话通过“设置成员未读消息计数（group.SetMemberUnreadMsgNum）”清除未读。
+// 单个会话清除失败时继续处理其余会话，全部处理完成后返回 *MarkAllReadError，列出清除失败的会话。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/50349
+// https://cloud.tencent.com/document/product/269/1637
+func (a *api) MarkAllRead(userId string) (err error) {
+	var (
+		peerUserIds  []string
+		c2cUnreads   = make(map[string]int)
+		groupUnreads = make(map[string]int)
+	)
+
+	err = a.recentcontact.PullSessions(&recentcontact.PullSessionsArg{
+		UserId:                  userId,
+		IsAllowTopSession:       true,
+		IsAllowTopSessionPaging: true,
+	}, func(ret *recentcontact.FetchSessionsRet) {
+		for _, session := range ret.List {
+			if session.Type == recentcontact.SessionTypeC2C {
+				peerUserIds = append(peerUserIds, session.UserId)
+			}
+		}
+	})
+	if err != nil {
+		return
+	}
+
+	if _, err = a.fetchC2CUnreads(userId, peerUserIds, c2cUnreads); err != nil {
+		return
+	}
+
+	if _, err = a.fetchGroupUnreads(userId, groupUnreads); err != nil {
+		return
+	}
+
+	// 单个会话清除失败时继续处理其余会话，最后汇总返回失败的会话
+	failed := &MarkAllReadError{FailedUsers: make(map[string]error), FailedGroups: make(map[string]error)}
+
+	for _, peerUserId := range sortedKeys(c2cUnreads) {
+		if c2cUnreads[peerUserId] > 0 {
+			if e := a.private.SetMessageRead(userId, peerUserId); e != nil {
+				failed.FailedUsers[peerUserId] = e
+			}
+		}
+	}
+
+	for _, groupId := range sortedKeys(groupUnreads) {
+		if groupUnreads[groupId] > 0 {
+			if e := a.group.SetMemberUnreadMsgNum(groupId, userId, 0); e != nil {
+				failed.FailedGroups[groupId] = e
+			}
+		}
+	}
+
+	if len(failed.FailedUsers) > 0 || len(failed.FailedGroups) > 0 {
+		err = failed
+	}
+
+	return
+}
+
+// fetchC2CUnreads 分批查询单聊会话的未读消息计数
+func (a *api) fetchC2CUnreads(userId string, peerUserIds []string, unreads map[string]int) (total int, err error) {
+	var ret *private.GetUnreadMessageNumRet
+
+	if len(peerUserIds) == 0 {
+		if ret, err = a.private.GetUnreadMessageNum(userId); err != nil {
+			return
+		}
+
+		return ret.Total, nil
+	}
+
+	for i := 0; i < len(peerUserIds); i += batchGetC2CUnreadLimit {
+		end := i + batchGetC2CUnreadLimit
+		if end > len(peerUserIds) {
+			end = len(peerUserIds)
+		}
+
+		if ret, err = a.private.GetUnreadMessageNum(userId, peerUserIds[i:end]...); err != nil {
+			return
+		}
+
+		total = ret.Total
+
+		for peerUserId, num := range ret.Results {
+			unreads[peerUserId] = num
+		}
+	}
+
+	return
+}
+
+// fetchGroupUnreads 查询用户所加入群组的未读消息计数
+func (a *api) fetchGroupUnreads(userId string, unreads map[string]int) (total int, err error) {
+	filter := &group.Filter{}
+	filter.AddBaseInfoFilter(group.BaseFieldGroupId)
+	filter.AddMemberInfoFilter(group.MemberFieldUnreadMsgNum)
+
+	err = a.group.PullMemberGroups(&group.PullMemberGroupsArg{
+		UserId: userId,
+		Limit:  100,
+		Filter: filter,
+	}, func(ret *group.FetchMemberGroupsRet) {
+		for _, g := range ret.List {
+			for _, member := range g.GetMembers() {
+				unreads[g.GetGroupId()] = member.GetUnreadMsgNum()
+				total += member.GetUnreadMsgNum()
+			}
+		}
+	})
+
+	return
+}
+
+// sortedKeys 获取按字典序排列的会话标识，保证处理顺序稳定
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
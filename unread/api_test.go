@@ -0,0 +1,94 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/19 16:00
+ * @Desc: 未读消息汇总测试
+ */
+
+package unread
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/default-yarns/tencent-im/group"
+	"github.com/default-yarns/tencent-im/private"
+	"github.com/default-yarns/tencent-im/recentcontact"
+)
+
+type (
+	stubRecentContactAPI struct {
+		recentcontact.API
+	}
+
+	stubPrivateAPI struct {
+		private.API
+		read []string
+	}
+
+	stubGroupAPI struct {
+		group.API
+		cleared []string
+		fail    map[string]bool
+	}
+)
+
+func (stubRecentContactAPI) PullSessions(arg *recentcontact.PullSessionsArg, fn func(ret *recentcontact.FetchSessionsRet)) error {
+	fn(&recentcontact.FetchSessionsRet{List: []*recentcontact.SessionItem{{Type: recentcontact.SessionTypeC2C, UserId: "u2"}}})
+	return nil
+}
+
+func (s *stubPrivateAPI) GetUnreadMessageNum(userId string, peerUserIds ...string) (*private.GetUnreadMessageNumRet, error) {
+	return &private.GetUnreadMessageNumRet{Total: 1, Results: map[string]int{"u2": 1}}, nil
+}
+
+func (s *stubPrivateAPI) SetMessageRead(userId, peerUserId string) error {
+	s.read = append(s.read, peerUserId)
+	return nil
+}
+
+func (s *stubGroupAPI) PullMemberGroups(arg *group.PullMemberGroupsArg, fn func(ret *group.FetchMemberGroupsRet)) error {
+	list := make([]*group.Group, 0, 3)
+	for _, groupId := range []string{"g1", "g2", "g3"} {
+		member := group.NewMember(arg.UserId)
+		member.SetUnreadMsgNum(1)
+		g := group.NewGroup(groupId)
+		g.AddMembers(member)
+		list = append(list, g)
+	}
+
+	fn(&group.FetchMemberGroupsRet{List: list})
+
+	return nil
+}
+
+func (s *stubGroupAPI) SetMemberUnreadMsgNum(groupId, userId string, unreadMsgNum int) error {
+	if s.fail[groupId] {
+		return errors.New("set unread failed")
+	}
+
+	s.cleared = append(s.cleared, groupId)
+
+	return nil
+}
+
+func TestMarkAllRead_ContinueOnFailure(t *testing.T) {
+	p := &stubPrivateAPI{}
+	g := &stubGroupAPI{fail: map[string]bool{"g1": true}}
+	a := &api{private: p, group: g, recentcontact: stubRecentContactAPI{}}
+
+	err := a.MarkAllRead("u1")
+
+	var e *MarkAllReadError
+	if !errors.As(err, &e) || len(e.FailedGroups) != 1 || e.FailedGroups["g1"] == nil || len(e.FailedUsers) != 0 {
+		t.Fatalf("expected g1 to fail, got %v", err)
+	}
+
+	if len(g.cleared) != 2 || g.cleared[0] != "g2" || g.cleared[1] != "g3" {
+		t.Fatalf("remaining groups should be cleared, got %v", g.cleared)
+	}
+
+	if len(p.read) != 1 || p.read[0] != "u2" {
+		t.Fatalf("c2c session should be marked read, got %v", p.read)
+	}
+}
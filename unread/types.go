@@ -0,0 +1,65 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2026/10/18 10:12
+ * @Desc: 未读消息汇总数据结构
+ */
+
+package unread
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/default-yarns/tencent-im/recentcontact"
+)
+
+type (
+	// Summary 未读消息汇总
+	Summary struct {
+		Total      int              // 未读消息总数（单聊与群聊之和）
+		C2CTotal   int              // 单聊未读消息总数（包含所有的单聊会话）
+		GroupTotal int              // 群聊未读消息总数（包含用户加入的所有群组）
+		Sessions   []*SessionUnread // 会话未读列表，顺序与会话列表保持一致
+	}
+
+	// SessionUnread 会话未读信息
+	SessionUnread struct {
+		Type         recentcontact.SessionType // 会话类型：1 表示 C2C 会话；2 表示 G2C 会话
+		UserId       string                    // C2C 会话的对方 UserID
+		GroupId      string                    // G2C 会话的群 ID
+		MsgTime      int                       // 会话时间
+		IsPinned     bool                      // 是否为置顶会话
+		UnreadMsgNum int                       // 会话未读消息数
+	}
+)
+
+// MarkAllReadError 标记所有会话已读时部分会话清除失败
+type MarkAllReadError struct {
+	FailedUsers  map[string]error // 清除失败的单聊会话，键为对方 UserID
+	FailedGroups map[string]error // 清除失败的群聊会话，键为群 ID
+}
+
+// Error 错误信息
+func (e *MarkAllReadError) Error() string {
+	parts := make([]string, 0, 2)
+	if len(e.FailedUsers) > 0 {
+		parts = append(parts, fmt.Sprintf("c2c sessions [%s]", strings.Join(sortedErrorKeys(e.FailedUsers), ", ")))
+	}
+	if len(e.FailedGroups) > 0 {
+		parts = append(parts, fmt.Sprintf("groups [%s]", strings.Join(sortedErrorKeys(e.FailedGroups), ", ")))
+	}
+
+	return "mark all read failed for " + strings.Join(parts, " and ")
+}
+
+func sortedErrorKeys(m map[string]error) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}